@@ -0,0 +1,347 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package appcheck contains functions for verifying Firebase App Check tokens.
+package appcheck
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/option"
+	"google.golang.org/api/transport"
+)
+
+const (
+	jwksURL         = "https://firebaseappcheck.googleapis.com/v1/jwks"
+	appCheckIssuer  = "https://firebaseappcheck.googleapis.com/"
+	algorithmRS256  = "RS256"
+	tokenType       = "JWT"
+	nonceClaim      = "nonce"
+	clockSkewPeriod = 5 * time.Minute
+
+	// defaultKeyMaxAge is how long the App Check public keys are cached when the JWKS response
+	// does not specify a max-age.
+	defaultKeyMaxAge = 6 * time.Hour
+
+	appCheckErrorCode = "appCheckErrorCode"
+	jwksFetchFailed   = "JWKS_FETCH_FAILED"
+	tokenExpired      = "APP_CHECK_TOKEN_EXPIRED"
+	tokenInvalid      = "APP_CHECK_TOKEN_INVALID"
+)
+
+// IsJWKSFetchFailed checks if the given error was caused by a failure to fetch the public keys
+// required to verify an App Check token.
+func IsJWKSFetchFailed(err error) bool {
+	return hasAppCheckErrorCode(err, jwksFetchFailed)
+}
+
+// IsTokenExpired checks if the given error was due to an expired App Check token.
+//
+// When IsTokenExpired returns true, IsTokenInvalid is guaranteed to return true.
+func IsTokenExpired(err error) bool {
+	return hasAppCheckErrorCode(err, tokenExpired)
+}
+
+// IsTokenInvalid checks if the given error was due to an invalid App Check token.
+//
+// An App Check token is considered invalid when it is malformed, expired, not signed by App
+// Check, or issued for a different project.
+func IsTokenInvalid(err error) bool {
+	return hasAppCheckErrorCode(err, tokenInvalid) || IsTokenExpired(err)
+}
+
+func hasAppCheckErrorCode(err error, code string) bool {
+	fe, ok := err.(*internal.FirebaseError)
+	if !ok {
+		return false
+	}
+
+	got, ok := fe.Ext[appCheckErrorCode]
+	return ok && got == code
+}
+
+// DecodedAppCheckToken represents a verified App Check token.
+//
+// Subject and AppID both contain the ID of the Firebase app the token was issued to. Claims
+// contains all the claims of the token, including the standard JWT claims.
+type DecodedAppCheckToken struct {
+	Issuer    string
+	Subject   string
+	Audience  []string
+	ExpiresAt time.Time
+	IssuedAt  time.Time
+	AppID     string
+	Claims    map[string]interface{}
+}
+
+// Client is the interface for the Firebase App Check service.
+type Client struct {
+	projectID string
+	keySource internal.KeySource
+	clock     internal.Clock
+}
+
+// NewClient creates a new instance of the Firebase App Check Client.
+//
+// This function can only be invoked from within the SDK. Client applications should access the
+// App Check service through firebase.App.
+func NewClient(ctx context.Context, c *internal.AppCheckConfig) (*Client, error) {
+	if c.ProjectID == "" {
+		return nil, errors.New("project id is required to access app check client")
+	}
+
+	noAuthHTTPClient, _, err := transport.NewHTTPClient(ctx, option.WithoutAuthentication())
+	if err != nil {
+		return nil, err
+	}
+	noAuthHTTPClient = c.WrapTransport.Wrap(noAuthHTTPClient)
+
+	ks := internal.NewHTTPKeySource(jwksURL, noAuthHTTPClient, internal.ParseJWKS)
+	ks.DefaultMaxAge = defaultKeyMaxAge
+	return &Client{
+		projectID: c.ProjectID,
+		keySource: ks,
+		clock:     internal.SystemClock,
+	}, nil
+}
+
+// VerifyToken verifies the given App Check token.
+//
+// VerifyToken considers a token to be valid if all the following conditions are met:
+//   - The token is a valid RS256 JWT, with a key ID (kid) header.
+//   - The JWT is signed by one of the App Check public keys.
+//   - The JWT is issued by App Check for a Firebase project, and its audience (aud) claim
+//     contains both that project's number and the ID of the project the Client belongs to.
+//   - The JWT is not expired, and it has been issued some time in the past.
+//
+// The App Check public keys are fetched from the App Check JWKS endpoint, and cached in memory
+// for as long as permitted by the response headers.
+//
+// If any of the above conditions are not met, an error is returned. Otherwise a pointer to a
+// decoded App Check token is returned.
+func (c *Client) VerifyToken(ctx context.Context, token string) (*DecodedAppCheckToken, error) {
+	if token == "" {
+		return nil, newInvalidTokenError(tokenInvalid, "app check token must be a non-empty string")
+	}
+
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return nil, newInvalidTokenError(tokenInvalid, "app check token has incorrect number of segments")
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		Type      string `json:"typ"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decode(segments[0], &header); err != nil {
+		return nil, newInvalidTokenError(tokenInvalid, "app check token has malformed header: %v", err)
+	}
+	if header.Algorithm != algorithmRS256 {
+		return nil, newInvalidTokenError(tokenInvalid,
+			"app check token has unsupported signing algorithm; expected %q but got %q",
+			algorithmRS256, header.Algorithm)
+	}
+	if header.Type != tokenType {
+		return nil, newInvalidTokenError(tokenInvalid,
+			"app check token has invalid type; expected %q but got %q", tokenType, header.Type)
+	}
+	if header.KeyID == "" {
+		return nil, newInvalidTokenError(tokenInvalid, "app check token has no 'kid' header")
+	}
+
+	decoded, err := c.verifyClaims(segments[1])
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := c.keySource.Keys(ctx)
+	if err != nil {
+		return nil, &internal.FirebaseError{
+			ErrorCode: internal.Unknown,
+			String:    err.Error(),
+			Ext:       map[string]interface{}{appCheckErrorCode: jwksFetchFailed},
+		}
+	}
+	if !verifySignature(segments, header.KeyID, keys) {
+		return nil, newInvalidTokenError(tokenInvalid, "failed to verify app check token signature")
+	}
+
+	return decoded, nil
+}
+
+// VerifyTokenWithNonce verifies the given App Check token, and checks that its nonce claim is
+// equal to expectedNonce.
+//
+// This binds the token to a single request: the client includes a nonce chosen by the server
+// when obtaining the token, and the server rejects tokens that do not carry the nonce it expects.
+// In addition to the nonce, the token must meet all the conditions checked by VerifyToken.
+func (c *Client) VerifyTokenWithNonce(ctx context.Context, token, expectedNonce string) (*DecodedAppCheckToken, error) {
+	if expectedNonce == "" {
+		return nil, errors.New("expected nonce must be a non-empty string")
+	}
+
+	decoded, err := c.VerifyToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, ok := decoded.Claims[nonceClaim].(string)
+	if !ok || nonce == "" {
+		return nil, newInvalidTokenError(tokenInvalid, "app check token has no 'nonce' claim")
+	}
+	if subtle.ConstantTimeCompare([]byte(nonce), []byte(expectedNonce)) != 1 {
+		return nil, newInvalidTokenError(tokenInvalid,
+			"app check token has invalid 'nonce' claim; it does not match the expected nonce")
+	}
+	return decoded, nil
+}
+
+func (c *Client) verifyClaims(segment string) (*DecodedAppCheckToken, error) {
+	var payload struct {
+		Issuer   string          `json:"iss"`
+		Subject  string          `json:"sub"`
+		Audience json.RawMessage `json:"aud"`
+		Expires  int64           `json:"exp"`
+		IssuedAt int64           `json:"iat"`
+	}
+	if err := decode(segment, &payload); err != nil {
+		return nil, newInvalidTokenError(tokenInvalid, "app check token has malformed payload: %v", err)
+	}
+
+	audience, err := parseAudience(payload.Audience)
+	if err != nil {
+		return nil, newInvalidTokenError(tokenInvalid, "app check token has malformed 'aud' claim: %v", err)
+	}
+
+	if !strings.HasPrefix(payload.Issuer, appCheckIssuer) {
+		return nil, newInvalidTokenError(tokenInvalid,
+			"app check token has invalid 'iss' (issuer) claim; expected prefix %q but got %q",
+			appCheckIssuer, payload.Issuer)
+	}
+	projectNumber := strings.TrimPrefix(payload.Issuer, appCheckIssuer)
+	if projectNumber == "" || !contains(audience, "projects/"+projectNumber) {
+		return nil, newInvalidTokenError(tokenInvalid,
+			"app check token has invalid 'aud' (audience) claim; expected it to contain %q "+
+				"but got %q", "projects/"+projectNumber, audience)
+	}
+	if !contains(audience, "projects/"+c.projectID) {
+		return nil, newInvalidTokenError(tokenInvalid,
+			"app check token has invalid 'aud' (audience) claim; expected it to contain %q "+
+				"but got %q; make sure the token comes from the same Firebase project as the "+
+				"credential used to authenticate this SDK", "projects/"+c.projectID, audience)
+	}
+	if payload.Subject == "" {
+		return nil, newInvalidTokenError(tokenInvalid, "app check token has empty 'sub' (subject) claim")
+	}
+
+	now := c.clock.Now()
+	issuedAt := time.Unix(payload.IssuedAt, 0)
+	expiresAt := time.Unix(payload.Expires, 0)
+	if issuedAt.Add(-clockSkewPeriod).After(now) {
+		return nil, newInvalidTokenError(tokenInvalid,
+			"app check token issued at future timestamp: %d", payload.IssuedAt)
+	}
+	if expiresAt.Add(clockSkewPeriod).Before(now) {
+		return nil, newInvalidTokenError(tokenExpired,
+			"app check token has expired at: %d", payload.Expires)
+	}
+
+	var claims map[string]interface{}
+	if err := decode(segment, &claims); err != nil {
+		return nil, newInvalidTokenError(tokenInvalid, "app check token has malformed payload: %v", err)
+	}
+
+	return &DecodedAppCheckToken{
+		Issuer:    payload.Issuer,
+		Subject:   payload.Subject,
+		Audience:  audience,
+		ExpiresAt: expiresAt,
+		IssuedAt:  issuedAt,
+		AppID:     payload.Subject,
+		Claims:    claims,
+	}, nil
+}
+
+func newInvalidTokenError(code string, format string, args ...interface{}) error {
+	return &internal.FirebaseError{
+		ErrorCode: internal.InvalidArgument,
+		String:    fmt.Sprintf(format, args...),
+		Ext:       map[string]interface{}{appCheckErrorCode: code},
+	}
+}
+
+// parseAudience parses the aud claim of a JWT, which may either be a single string or an array
+// of strings.
+func parseAudience(b json.RawMessage) ([]string, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	var audience []string
+	if err := json.Unmarshal(b, &audience); err == nil {
+		return audience, nil
+	}
+	var single string
+	if err := json.Unmarshal(b, &single); err != nil {
+		return nil, err
+	}
+	return []string{single}, nil
+}
+
+func contains(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func decode(segment string, i interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.NewDecoder(bytes.NewBuffer(decoded)).Decode(i)
+}
+
+func verifySignature(segments []string, kid string, keys []*internal.PublicKey) bool {
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return false
+	}
+
+	h := sha256.New()
+	h.Write([]byte(segments[0] + "." + segments[1]))
+	digest := h.Sum(nil)
+	for _, k := range keys {
+		if k.Kid == kid && rsa.VerifyPKCS1v15(k.Key, crypto.SHA256, digest, signature) == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,365 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appcheck
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/errorutils"
+	"firebase.google.com/go/v4/internal"
+)
+
+const (
+	testProjectID     = "test-project"
+	testProjectNumber = "123456789"
+	testAppID         = "1:123456789:web:abcdef"
+	testKeyID         = "test-key"
+)
+
+var (
+	testPrivateKey *rsa.PrivateKey
+	testNow        = time.Unix(1700000000, 0)
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	testPrivateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(m.Run())
+}
+
+type mockKeySource struct {
+	keys []*internal.PublicKey
+	err  error
+}
+
+func (k *mockKeySource) Keys(ctx context.Context) ([]*internal.PublicKey, error) {
+	return k.keys, k.err
+}
+
+func newTestClient() *Client {
+	return &Client{
+		projectID: testProjectID,
+		keySource: &mockKeySource{
+			keys: []*internal.PublicKey{{Kid: testKeyID, Key: &testPrivateKey.PublicKey}},
+		},
+		clock: &internal.MockClock{Timestamp: testNow},
+	}
+}
+
+func defaultHeader() map[string]interface{} {
+	return map[string]interface{}{"alg": "RS256", "typ": "JWT", "kid": testKeyID}
+}
+
+func defaultPayload() map[string]interface{} {
+	return map[string]interface{}{
+		"iss": appCheckIssuer + testProjectNumber,
+		"sub": testAppID,
+		"aud": []string{"projects/" + testProjectNumber, "projects/" + testProjectID},
+		"iat": testNow.Add(-time.Minute).Unix(),
+		"exp": testNow.Add(time.Hour).Unix(),
+	}
+}
+
+func signToken(t *testing.T, header, payload map[string]interface{}) string {
+	encode := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	content := encode(header) + "." + encode(payload)
+	digest := sha256.Sum256([]byte(content))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, testPrivateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestNoProjectID(t *testing.T) {
+	client, err := NewClient(context.Background(), &internal.AppCheckConfig{})
+	if client != nil || err == nil {
+		t.Errorf("NewClient() = (%v, %v); want = (nil, error)", client, err)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	client, err := NewClient(context.Background(), &internal.AppCheckConfig{ProjectID: testProjectID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ks, ok := client.keySource.(*internal.HTTPKeySource)
+	if !ok {
+		t.Fatalf("Client.keySource = %#v; want = HTTPKeySource", client.keySource)
+	}
+	if ks.KeyURI != jwksURL {
+		t.Errorf("KeyURI = %q; want = %q", ks.KeyURI, jwksURL)
+	}
+	if ks.DefaultMaxAge != defaultKeyMaxAge {
+		t.Errorf("DefaultMaxAge = %v; want = %v", ks.DefaultMaxAge, defaultKeyMaxAge)
+	}
+}
+
+func TestVerifyToken(t *testing.T) {
+	client := newTestClient()
+	token := signToken(t, defaultHeader(), defaultPayload())
+
+	decoded, err := client.VerifyToken(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &DecodedAppCheckToken{
+		Issuer:    appCheckIssuer + testProjectNumber,
+		Subject:   testAppID,
+		Audience:  []string{"projects/" + testProjectNumber, "projects/" + testProjectID},
+		ExpiresAt: testNow.Add(time.Hour),
+		IssuedAt:  testNow.Add(-time.Minute),
+		AppID:     testAppID,
+		Claims: map[string]interface{}{
+			"iss": appCheckIssuer + testProjectNumber,
+			"sub": testAppID,
+			"aud": []interface{}{"projects/" + testProjectNumber, "projects/" + testProjectID},
+			"iat": float64(testNow.Add(-time.Minute).Unix()),
+			"exp": float64(testNow.Add(time.Hour).Unix()),
+		},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("VerifyToken() = %#v; want = %#v", decoded, want)
+	}
+}
+
+func TestVerifyTokenError(t *testing.T) {
+	client := newTestClient()
+	with := func(key string, value interface{}) map[string]interface{} {
+		p := defaultPayload()
+		if value == nil {
+			delete(p, key)
+		} else {
+			p[key] = value
+		}
+		return p
+	}
+	withHeader := func(key string, value interface{}) map[string]interface{} {
+		h := defaultHeader()
+		h[key] = value
+		return h
+	}
+	valid := signToken(t, defaultHeader(), defaultPayload())
+	segments := strings.Split(valid, ".")
+
+	cases := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"Empty", "", "app check token must be a non-empty string"},
+		{"Segments", "foo.bar", "app check token has incorrect number of segments"},
+		{
+			"Algorithm",
+			signToken(t, withHeader("alg", "HS256"), defaultPayload()),
+			`app check token has unsupported signing algorithm; expected "RS256" but got "HS256"`,
+		},
+		{
+			"Type",
+			signToken(t, withHeader("typ", "JWS"), defaultPayload()),
+			`app check token has invalid type; expected "JWT" but got "JWS"`,
+		},
+		{
+			"KeyID",
+			signToken(t, withHeader("kid", ""), defaultPayload()),
+			"app check token has no 'kid' header",
+		},
+		{
+			"Issuer",
+			signToken(t, defaultHeader(), with("iss", "https://securetoken.google.com/"+testProjectID)),
+			`app check token has invalid 'iss' (issuer) claim; expected prefix ` +
+				`"https://firebaseappcheck.googleapis.com/" but got "https://securetoken.google.com/test-project"`,
+		},
+		{
+			"ProjectNumber",
+			signToken(t, defaultHeader(), with("iss", appCheckIssuer+"987654321")),
+			`app check token has invalid 'aud' (audience) claim; expected it to contain ` +
+				`"projects/987654321" but got ["projects/123456789" "projects/test-project"]`,
+		},
+		{
+			"ProjectID",
+			signToken(t, defaultHeader(), with("aud", []string{"projects/" + testProjectNumber, "projects/other"})),
+			`app check token has invalid 'aud' (audience) claim; expected it to contain ` +
+				`"projects/test-project" but got ["projects/123456789" "projects/other"]; make sure the ` +
+				`token comes from the same Firebase project as the credential used to authenticate this SDK`,
+		},
+		{
+			"Subject",
+			signToken(t, defaultHeader(), with("sub", nil)),
+			"app check token has empty 'sub' (subject) claim",
+		},
+		{
+			"FutureIssuedAt",
+			signToken(t, defaultHeader(), with("iat", testNow.Add(time.Hour).Unix())),
+			fmt.Sprintf("app check token issued at future timestamp: %d", testNow.Add(time.Hour).Unix()),
+		},
+		{
+			"Signature",
+			segments[0] + "." + segments[1] + ".invalid",
+			"failed to verify app check token signature",
+		},
+		{
+			"WrongKey",
+			signToken(t, withHeader("kid", "other-key"), defaultPayload()),
+			"failed to verify app check token signature",
+		},
+	}
+
+	for _, tc := range cases {
+		decoded, err := client.VerifyToken(context.Background(), tc.token)
+		if decoded != nil || err == nil || err.Error() != tc.want {
+			t.Errorf("VerifyToken(%s) = (%v, %v); want = (nil, %q)", tc.name, decoded, err, tc.want)
+		}
+		if !IsTokenInvalid(err) || !errorutils.IsInvalidArgument(err) {
+			t.Errorf("VerifyToken(%s) = %v; want = TokenInvalid", tc.name, err)
+		}
+		if IsTokenExpired(err) {
+			t.Errorf("IsTokenExpired(%s) = true; want = false", tc.name)
+		}
+	}
+}
+
+func TestVerifyTokenExpired(t *testing.T) {
+	client := newTestClient()
+	payload := defaultPayload()
+	payload["exp"] = testNow.Add(-time.Hour).Unix()
+	token := signToken(t, defaultHeader(), payload)
+
+	decoded, err := client.VerifyToken(context.Background(), token)
+	want := fmt.Sprintf("app check token has expired at: %d", testNow.Add(-time.Hour).Unix())
+	if decoded != nil || err == nil || err.Error() != want {
+		t.Errorf("VerifyToken() = (%v, %v); want = (nil, %q)", decoded, err, want)
+	}
+	if !IsTokenExpired(err) || !IsTokenInvalid(err) {
+		t.Errorf("VerifyToken() = %v; want = TokenExpired", err)
+	}
+}
+
+func TestVerifyTokenSingleAudience(t *testing.T) {
+	client := newTestClient()
+	client.projectID = testProjectNumber
+	payload := defaultPayload()
+	payload["aud"] = "projects/" + testProjectNumber
+	token := signToken(t, defaultHeader(), payload)
+
+	decoded, err := client.VerifyToken(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"projects/" + testProjectNumber}; !reflect.DeepEqual(decoded.Audience, want) {
+		t.Errorf("Audience = %v; want = %v", decoded.Audience, want)
+	}
+}
+
+func TestVerifyTokenWithNonce(t *testing.T) {
+	client := newTestClient()
+	payload := defaultPayload()
+	payload["nonce"] = "test-nonce"
+	token := signToken(t, defaultHeader(), payload)
+
+	decoded, err := client.VerifyTokenWithNonce(context.Background(), token, "test-nonce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Claims["nonce"] != "test-nonce" {
+		t.Errorf("Claims[nonce] = %v; want = %q", decoded.Claims["nonce"], "test-nonce")
+	}
+}
+
+func TestVerifyTokenWithNonceError(t *testing.T) {
+	client := newTestClient()
+	withNonce := func(nonce interface{}) string {
+		payload := defaultPayload()
+		if nonce != nil {
+			payload["nonce"] = nonce
+		}
+		return signToken(t, defaultHeader(), payload)
+	}
+
+	cases := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{
+			"Mismatch",
+			withNonce("other-nonce"),
+			"app check token has invalid 'nonce' claim; it does not match the expected nonce",
+		},
+		{"Missing", withNonce(nil), "app check token has no 'nonce' claim"},
+		{"NotString", withNonce(42), "app check token has no 'nonce' claim"},
+		{"InvalidToken", "foo.bar", "app check token has incorrect number of segments"},
+	}
+
+	for _, tc := range cases {
+		decoded, err := client.VerifyTokenWithNonce(context.Background(), tc.token, "test-nonce")
+		if decoded != nil || err == nil || err.Error() != tc.want {
+			t.Errorf("VerifyTokenWithNonce(%s) = (%v, %v); want = (nil, %q)", tc.name, decoded, err, tc.want)
+		}
+		if !IsTokenInvalid(err) {
+			t.Errorf("VerifyTokenWithNonce(%s) = %v; want = TokenInvalid", tc.name, err)
+		}
+	}
+}
+
+func TestVerifyTokenWithEmptyNonce(t *testing.T) {
+	client := newTestClient()
+	token := signToken(t, defaultHeader(), defaultPayload())
+
+	decoded, err := client.VerifyTokenWithNonce(context.Background(), token, "")
+	if decoded != nil || err == nil || IsTokenInvalid(err) {
+		t.Errorf("VerifyTokenWithNonce(\"\") = (%v, %v); want = (nil, error)", decoded, err)
+	}
+}
+
+func TestVerifyTokenJWKSFetchFailed(t *testing.T) {
+	client := newTestClient()
+	client.keySource = &mockKeySource{err: errors.New("jwks fetch error")}
+	token := signToken(t, defaultHeader(), defaultPayload())
+
+	decoded, err := client.VerifyToken(context.Background(), token)
+	if decoded != nil || err == nil || err.Error() != "jwks fetch error" {
+		t.Errorf("VerifyToken() = (%v, %v); want = (nil, %q)", decoded, err, "jwks fetch error")
+	}
+	if !IsJWKSFetchFailed(err) || IsTokenInvalid(err) {
+		t.Errorf("VerifyToken() = %v; want = JWKSFetchFailed", err)
+	}
+}
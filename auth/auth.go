@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -31,17 +32,20 @@ import (
 )
 
 const (
-	authErrorCode      = "authErrorCode"
-	emulatorHostEnvVar = "FIREBASE_AUTH_EMULATOR_HOST"
-	defaultAuthURL     = "https://identitytoolkit.googleapis.com"
-	firebaseAudience   = "https://identitytoolkit.googleapis.com/google.identity.identitytoolkit.v1.IdentityToolkit"
-	oneHourInSeconds   = 3600
+	authErrorCode        = "authErrorCode"
+	emulatorHostEnvVar   = "FIREBASE_AUTH_EMULATOR_HOST"
+	defaultAuthURL       = "https://identitytoolkit.googleapis.com"
+	googleSignInProvider = "google.com"
+	firebaseAudience     = "https://identitytoolkit.googleapis.com/google.identity.identitytoolkit.v1.IdentityToolkit"
+	oneHourInSeconds     = 3600
 
 	// SDK-generated error codes
 	idTokenRevoked       = "ID_TOKEN_REVOKED"
 	userDisabled         = "USER_DISABLED"
 	sessionCookieRevoked = "SESSION_COOKIE_REVOKED"
 	tenantIDMismatch     = "TENANT_ID_MISMATCH"
+	hostedDomainMismatch = "HOSTED_DOMAIN_MISMATCH"
+	authHeaderInvalid    = "AUTHORIZATION_HEADER_INVALID"
 )
 
 var reservedClaims = []string{
@@ -110,12 +114,12 @@ func NewClient(ctx context.Context, conf *internal.AuthConfig) (*Client, error)
 		}
 	}
 
-	idTokenVerifier, err := newIDTokenVerifier(ctx, conf.ProjectID)
+	idTokenVerifier, err := newIDTokenVerifier(ctx, conf)
 	if err != nil {
 		return nil, err
 	}
 
-	cookieVerifier, err := newSessionCookieVerifier(ctx, conf.ProjectID)
+	cookieVerifier, err := newSessionCookieVerifier(ctx, conf)
 	if err != nil {
 		return nil, err
 	}
@@ -133,13 +137,16 @@ func NewClient(ctx context.Context, conf *internal.AuthConfig) (*Client, error)
 		return nil, err
 	}
 
-	hc := internal.WithDefaultRetryConfig(transport)
+	hc := internal.WithDefaultRetryConfig(conf.WrapTransport.Wrap(transport))
 	hc.CreateErrFn = handleHTTPError
 	hc.Opts = []internal.HTTPOption{
 		internal.WithHeader("X-Client-Version", fmt.Sprintf("Go/Admin/%s", conf.Version)),
 	}
 
 	baseURL := defaultAuthURL
+	if conf.Endpoint != "" {
+		baseURL = strings.TrimSuffix(conf.Endpoint, "/")
+	}
 	if isEmulator {
 		baseURL = fmt.Sprintf("http://%s/identitytoolkit.googleapis.com", authEmulatorHost)
 	}
@@ -247,16 +254,60 @@ func (c *Client) SessionCookie(
 // Token provides typed accessors to the common JWT fields such as Audience (aud) and Expiry (exp).
 // Additionally it provides a UID field, which indicates the user ID of the account to which this token
 // belongs. Any additional JWT claims can be accessed via the Claims map of Token.
+//
+// The Email and EmailVerified fields are populated from the email and email_verified claims.
+// EmailVerified is normalized to a bool, regardless of whether the identity provider encoded the
+// claim as a JSON boolean or as a string.
+//
+// VerifiedAt is the time at which the SDK verified the token, as reported by the clock of the
+// verifier. It is not a JWT claim, and it is the zero time for tokens that were not returned by
+// one of the Verify methods of Client.
 type Token struct {
-	AuthTime int64                  `json:"auth_time"`
-	Issuer   string                 `json:"iss"`
-	Audience string                 `json:"aud"`
-	Expires  int64                  `json:"exp"`
-	IssuedAt int64                  `json:"iat"`
-	Subject  string                 `json:"sub,omitempty"`
-	UID      string                 `json:"uid,omitempty"`
-	Firebase FirebaseInfo           `json:"firebase"`
-	Claims   map[string]interface{} `json:"-"`
+	AuthTime      int64                  `json:"auth_time"`
+	Issuer        string                 `json:"iss"`
+	Audience      string                 `json:"aud"`
+	Expires       int64                  `json:"exp"`
+	IssuedAt      int64                  `json:"iat"`
+	Subject       string                 `json:"sub,omitempty"`
+	UID           string                 `json:"uid,omitempty"`
+	Email         string                 `json:"email,omitempty"`
+	EmailVerified bool                   `json:"-"`
+	Firebase      FirebaseInfo           `json:"firebase"`
+	Claims        map[string]interface{} `json:"-"`
+	VerifiedAt    time.Time              `json:"-"`
+}
+
+// ExpiresIn returns the amount of time that remained until this token expires when it was
+// verified.
+//
+// The returned duration is the difference between the exp claim and VerifiedAt. If VerifiedAt is
+// not set, the current time is used instead. It may be negative for tokens that have expired, but
+// are still accepted due to the allowed clock skew.
+func (t *Token) ExpiresIn() time.Duration {
+	now := t.VerifiedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return time.Unix(t.Expires, 0).Sub(now)
+}
+
+// hostedDomain returns the lower-cased Google Workspace domain of the user to whom this token
+// belongs, or an empty string if the domain cannot be determined.
+//
+// The domain of the email is only used for users who signed in with Google, since other identity
+// providers may assert verified addresses in any domain.
+func (t *Token) hostedDomain() string {
+	if hd, ok := t.Claims["hd"].(string); ok && hd != "" {
+		return strings.ToLower(hd)
+	}
+
+	if t.Firebase.SignInProvider != googleSignInProvider {
+		return ""
+	}
+	if sep := strings.LastIndex(t.Email, "@"); t.EmailVerified && sep != -1 {
+		return strings.ToLower(t.Email[sep+1:])
+	}
+	return ""
 }
 
 // FirebaseInfo represents the information about the sign-in event, including which auth provider
@@ -276,6 +327,7 @@ type baseClient struct {
 	tenantMgtEndpoint      string
 	projectID              string
 	tenantID               string
+	hostedDomain           string
 	httpClient             *internal.HTTPClient
 	idTokenVerifier        *tokenVerifier
 	cookieVerifier         *tokenVerifier
@@ -290,6 +342,36 @@ func (c *baseClient) withTenantID(tenantID string) *baseClient {
 	return &copy
 }
 
+func (c *baseClient) withHostedDomain(domain string) *baseClient {
+	copy := *c
+	copy.hostedDomain = strings.ToLower(domain)
+	return &copy
+}
+
+// WithRequiredHostedDomain returns a copy of this Client that only accepts ID tokens issued to
+// users of the specified Google Workspace hosted domain.
+//
+// The hosted domain of a token is read from its `hd` claim. When that claim is absent, and the
+// user signed in with Google (the google.com sign-in provider), the domain of the `email` claim
+// is used instead, provided the email address has been verified. ID tokens and session cookies
+// that do not belong to the domain are rejected by VerifyIDToken, VerifyIDTokenAndCheckRevoked,
+// VerifySessionCookie and VerifySessionCookieAndCheckRevoked with an error for which
+// IsHostedDomainMismatch returns true.
+//
+// Firebase ID tokens only carry a top-level `hd` claim when the developer has set it as a custom
+// claim, so most tokens are checked against the email domain. A verified Google email proves
+// that the user controls a mailbox in the domain, not that they are a member of the Google
+// Workspace organization. Applications that require Workspace membership should set the `hd`
+// custom claim from a trusted source.
+//
+// The tenant clients obtained from TenantManager do not enforce the domain.
+func (c *Client) WithRequiredHostedDomain(domain string) *Client {
+	return &Client{
+		baseClient:    c.baseClient.withHostedDomain(domain),
+		TenantManager: c.TenantManager,
+	}
+}
+
 // VerifyIDToken verifies the signature	and payload of the provided ID token.
 //
 // VerifyIDToken accepts a signed JWT token string, and verifies that it is current, issued for the
@@ -319,6 +401,52 @@ func (c *baseClient) VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken s
 	return c.verifyIDToken(ctx, idToken, true)
 }
 
+// VerifyIDTokenFromHeader extracts a bearer token from the Authorization header, and verifies it
+// as an ID token.
+//
+// The header must be of the form `Bearer <idToken>`. The scheme is matched case-insensitively and
+// surrounding whitespace is ignored. If the header is missing or malformed, an error for which
+// IsAuthorizationHeaderInvalid returns true is returned without attempting verification.
+// Otherwise this behaves exactly like VerifyIDToken.
+func (c *baseClient) VerifyIDTokenFromHeader(ctx context.Context, header http.Header) (*Token, error) {
+	idToken, err := bearerToken(header)
+	if err != nil {
+		return nil, err
+	}
+	return c.VerifyIDToken(ctx, idToken)
+}
+
+func bearerToken(header http.Header) (string, error) {
+	value := strings.TrimSpace(header.Get("Authorization"))
+	if value == "" {
+		return "", &internal.FirebaseError{
+			ErrorCode: internal.InvalidArgument,
+			String:    "authorization header is missing",
+			Ext: map[string]interface{}{
+				authErrorCode: authHeaderInvalid,
+			},
+		}
+	}
+
+	parts := strings.Fields(value)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return "", &internal.FirebaseError{
+			ErrorCode: internal.InvalidArgument,
+			String:    "authorization header must be of the form 'Bearer <token>'",
+			Ext: map[string]interface{}{
+				authErrorCode: authHeaderInvalid,
+			},
+		}
+	}
+	return parts[1], nil
+}
+
+// IsAuthorizationHeaderInvalid checks if the given error was due to a missing or malformed
+// Authorization header.
+func IsAuthorizationHeaderInvalid(err error) bool {
+	return hasAuthErrorCode(err, authHeaderInvalid)
+}
+
 func (c *baseClient) verifyIDToken(ctx context.Context, idToken string, checkRevokedOrDisabled bool) (*Token, error) {
 	decoded, err := c.idTokenVerifier.VerifyToken(ctx, idToken, c.isEmulator)
 	if err != nil {
@@ -335,6 +463,10 @@ func (c *baseClient) verifyIDToken(ctx context.Context, idToken string, checkRev
 		}
 	}
 
+	if err := c.checkHostedDomain(decoded, "ID token"); err != nil {
+		return nil, err
+	}
+
 	if c.isEmulator || checkRevokedOrDisabled {
 		err = c.checkRevokedOrDisabled(ctx, decoded, idTokenRevoked, "ID token has been revoked")
 		if err != nil {
@@ -350,6 +482,12 @@ func IsTenantIDMismatch(err error) bool {
 	return hasAuthErrorCode(err, tenantIDMismatch)
 }
 
+// IsHostedDomainMismatch checks if the given error was due to an ID token or session cookie that
+// does not belong to the hosted domain required by the Client.
+func IsHostedDomainMismatch(err error) bool {
+	return hasAuthErrorCode(err, hostedDomainMismatch)
+}
+
 // IsIDTokenRevoked checks if the given error was due to a revoked ID token.
 //
 // When IsIDTokenRevoked returns true, IsIDTokenInvalid is guaranteed to return true.
@@ -398,6 +536,10 @@ func (c *Client) verifySessionCookie(ctx context.Context, sessionCookie string,
 		return nil, err
 	}
 
+	if err := c.checkHostedDomain(decoded, "session cookie"); err != nil {
+		return nil, err
+	}
+
 	if c.isEmulator || checkRevokedOrDisabled {
 		err := c.checkRevokedOrDisabled(ctx, decoded, sessionCookieRevoked, "session cookie has been revoked")
 		if err != nil {
@@ -408,6 +550,23 @@ func (c *Client) verifySessionCookie(ctx context.Context, sessionCookie string,
 	return decoded, nil
 }
 
+// checkHostedDomain checks that the given token belongs to the hosted domain required by the
+// client, if any.
+func (c *baseClient) checkHostedDomain(token *Token, shortName string) error {
+	if c.hostedDomain == "" || token.hostedDomain() == c.hostedDomain {
+		return nil
+	}
+
+	return &internal.FirebaseError{
+		ErrorCode: internal.PermissionDenied,
+		String: fmt.Sprintf("%s does not belong to the hosted domain %q: %q",
+			shortName, c.hostedDomain, token.hostedDomain()),
+		Ext: map[string]interface{}{
+			authErrorCode: hostedDomainMismatch,
+		},
+	}
+}
+
 // IsSessionCookieRevoked checks if the given error was due to a revoked session cookie.
 //
 // When IsSessionCookieRevoked returns true, IsSessionCookieInvalid is guaranteed to return true.
@@ -316,6 +316,28 @@ func TestNewClientEmulatorHostEnvVar(t *testing.T) {
 	}
 }
 
+func TestNewClientWithEndpoint(t *testing.T) {
+	conf := &internal.AuthConfig{
+		Opts:     optsWithTokenSource,
+		Endpoint: "https://auth.example.com",
+	}
+	client, err := NewClient(context.Background(), conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseClient := client.baseClient
+	if baseClient.userManagementEndpoint != "https://auth.example.com/v1" {
+		t.Errorf("baseClient.userManagementEndpoint = %q; want = %q", baseClient.userManagementEndpoint, "https://auth.example.com/v1")
+	}
+	if baseClient.providerConfigEndpoint != "https://auth.example.com/v2" {
+		t.Errorf("baseClient.providerConfigEndpoint = %q; want = %q", baseClient.providerConfigEndpoint, "https://auth.example.com/v2")
+	}
+	if baseClient.tenantMgtEndpoint != "https://auth.example.com/v2" {
+		t.Errorf("baseClient.tenantMgtEndpoint = %q; want = %q", baseClient.tenantMgtEndpoint, "https://auth.example.com/v2")
+	}
+}
+
 func TestCustomToken(t *testing.T) {
 	client := &Client{
 		baseClient: &baseClient{
@@ -504,6 +526,279 @@ func TestVerifyIDTokenFromTenant(t *testing.T) {
 	}
 }
 
+func TestVerifyIDTokenFromHeader(t *testing.T) {
+	client := &Client{
+		baseClient: &baseClient{
+			idTokenVerifier: testIDTokenVerifier,
+		},
+	}
+
+	for _, value := range []string{
+		"Bearer " + testIDToken,
+		"bearer " + testIDToken,
+		"BEARER  " + testIDToken,
+		"  Bearer " + testIDToken + "  ",
+	} {
+		header := http.Header{}
+		header.Set("Authorization", value)
+		ft, err := client.VerifyIDTokenFromHeader(context.Background(), header)
+		if err != nil {
+			t.Fatalf("VerifyIDTokenFromHeader(%q) = %v; want = nil", value, err)
+		}
+		if ft.UID != ft.Subject {
+			t.Errorf("UID = %q; Sub = %q; want UID = Sub", ft.UID, ft.Subject)
+		}
+	}
+}
+
+func TestVerifyIDTokenFromHeaderError(t *testing.T) {
+	client := &Client{
+		baseClient: &baseClient{
+			idTokenVerifier: testIDTokenVerifier,
+		},
+	}
+
+	cases := []struct {
+		name, value, want string
+	}{
+		{"Missing", "", "authorization header is missing"},
+		{"Blank", "   ", "authorization header is missing"},
+		{"NoScheme", testIDToken, "authorization header must be of the form 'Bearer <token>'"},
+		{"NoToken", "Bearer", "authorization header must be of the form 'Bearer <token>'"},
+		{"WrongScheme", "Basic " + testIDToken, "authorization header must be of the form 'Bearer <token>'"},
+		{"ExtraParts", "Bearer foo bar", "authorization header must be of the form 'Bearer <token>'"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.value != "" {
+				header.Set("Authorization", tc.value)
+			}
+			ft, err := client.VerifyIDTokenFromHeader(context.Background(), header)
+			if ft != nil || err == nil || err.Error() != tc.want {
+				t.Errorf("VerifyIDTokenFromHeader(%q) = (%v, %v); want = (nil, %q)", tc.value, ft, err, tc.want)
+			}
+			if !IsAuthorizationHeaderInvalid(err) || IsIDTokenInvalid(err) {
+				t.Errorf("VerifyIDTokenFromHeader(%q) = %v; want = AuthorizationHeaderInvalid", tc.value, err)
+			}
+		})
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer invalid-token")
+	_, err := client.VerifyIDTokenFromHeader(context.Background(), header)
+	if !IsIDTokenInvalid(err) || IsAuthorizationHeaderInvalid(err) {
+		t.Errorf("VerifyIDTokenFromHeader('invalid-token') = %v; want = IDTokenInvalid", err)
+	}
+}
+
+func TestVerifyIDTokenEmailVerified(t *testing.T) {
+	client := &Client{
+		baseClient: &baseClient{
+			idTokenVerifier: testIDTokenVerifier,
+		},
+	}
+
+	cases := []struct {
+		name          string
+		emailVerified interface{}
+		want          bool
+	}{
+		{"Bool", true, true},
+		{"BoolFalse", false, false},
+		{"String", "true", true},
+		{"StringUpperCase", "TRUE", true},
+		{"StringFalse", "false", false},
+		{"InvalidString", "yes", false},
+		{"Number", 1, false},
+		{"Missing", nil, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload := mockIDTokenPayload{"email": "alice@example.com"}
+			if tc.emailVerified != nil {
+				payload["email_verified"] = tc.emailVerified
+			}
+
+			ft, err := client.VerifyIDToken(context.Background(), getIDToken(payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ft.Email != "alice@example.com" {
+				t.Errorf("Email = %q; want = %q", ft.Email, "alice@example.com")
+			}
+			if ft.EmailVerified != tc.want {
+				t.Errorf("EmailVerified = %v; want = %v", ft.EmailVerified, tc.want)
+			}
+			if ft.Claims["email"] != "alice@example.com" {
+				t.Errorf("Claims['email'] = %v; want = %q", ft.Claims["email"], "alice@example.com")
+			}
+		})
+	}
+}
+
+func TestVerifyIDTokenExpiresIn(t *testing.T) {
+	client := &Client{
+		baseClient: &baseClient{
+			idTokenVerifier: testIDTokenVerifier,
+		},
+	}
+
+	cases := []struct {
+		name  string
+		exp   int64
+		token string
+	}{
+		{"Valid", 3600, testIDToken},
+		{"WithinClockSkew", -60, getIDToken(mockIDTokenPayload{
+			"iat": testClock.Now().Unix() - 1000,
+			"exp": testClock.Now().Unix() - 60,
+		})},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ft, err := client.VerifyIDToken(context.Background(), tc.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !ft.VerifiedAt.Equal(testClock.Now()) {
+				t.Errorf("VerifiedAt = %v; want = %v", ft.VerifiedAt, testClock.Now())
+			}
+			want := time.Unix(testClock.Now().Unix()+tc.exp, 0).Sub(testClock.Now())
+			if got := ft.ExpiresIn(); got != want {
+				t.Errorf("ExpiresIn() = %v; want = %v", got, want)
+			}
+		})
+	}
+}
+
+// googleSignIn is the firebase claim of a token issued to a user who signed in with Google.
+var googleSignIn = map[string]interface{}{
+	"identities":       map[string]interface{}{},
+	"sign_in_provider": "google.com",
+}
+
+func TestVerifyIDTokenWithRequiredHostedDomain(t *testing.T) {
+	client := (&Client{
+		baseClient: &baseClient{
+			idTokenVerifier: testIDTokenVerifier,
+		},
+	}).WithRequiredHostedDomain("Example.com")
+
+	cases := []struct {
+		name  string
+		token string
+	}{
+		{"HostedDomainClaim", getIDToken(mockIDTokenPayload{"hd": "example.com"})},
+		{"VerifiedEmail", getIDToken(mockIDTokenPayload{
+			"email":          "alice@EXAMPLE.com",
+			"email_verified": true,
+			"firebase":       googleSignIn,
+		})},
+		{"StringEncodedVerifiedEmail", getIDToken(mockIDTokenPayload{
+			"email":          "alice@example.com",
+			"email_verified": "true",
+			"firebase":       googleSignIn,
+		})},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ft, err := client.VerifyIDToken(context.Background(), tc.token)
+			if err != nil {
+				t.Fatalf("VerifyIDToken(%q) = (%v, %v); want = (token, nil)", tc.name, ft, err)
+			}
+			if ft.UID != ft.Subject {
+				t.Errorf("UID = %q; Sub = %q; want UID = Sub", ft.UID, ft.Subject)
+			}
+		})
+	}
+}
+
+func TestVerifyIDTokenWithRequiredHostedDomainError(t *testing.T) {
+	client := (&Client{
+		baseClient: &baseClient{
+			idTokenVerifier: testIDTokenVerifier,
+		},
+	}).WithRequiredHostedDomain("example.com")
+
+	cases := []struct {
+		name  string
+		token string
+	}{
+		{"NoDomain", testIDToken},
+		{"OtherHostedDomain", getIDToken(mockIDTokenPayload{"hd": "other.com"})},
+		{"OtherEmailDomain", getIDToken(mockIDTokenPayload{
+			"email":          "alice@other.com",
+			"email_verified": true,
+			"firebase":       googleSignIn,
+		})},
+		{"UnverifiedEmail", getIDToken(mockIDTokenPayload{
+			"email":          "alice@example.com",
+			"email_verified": false,
+			"firebase":       googleSignIn,
+		})},
+		{"NonGoogleProvider", getIDToken(mockIDTokenPayload{
+			"email":          "alice@example.com",
+			"email_verified": true,
+			"firebase": map[string]interface{}{
+				"identities":       map[string]interface{}{},
+				"sign_in_provider": "oidc.example",
+			},
+		})},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ft, err := client.VerifyIDToken(context.Background(), tc.token)
+			if ft != nil || !IsHostedDomainMismatch(err) {
+				t.Errorf("VerifyIDToken(%q) = (%v, %v); want = (nil, HostedDomainMismatch)", tc.name, ft, err)
+			}
+			if !errorutils.IsPermissionDenied(err) {
+				t.Errorf("VerifyIDToken(%q) = %v; want = PermissionDenied", tc.name, err)
+			}
+		})
+	}
+}
+
+func TestVerifySessionCookieWithRequiredHostedDomain(t *testing.T) {
+	client := (&Client{
+		baseClient: &baseClient{
+			cookieVerifier: testCookieVerifier,
+		},
+	}).WithRequiredHostedDomain("example.com")
+
+	cookie := getSessionCookie(mockIDTokenPayload{
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"firebase":       googleSignIn,
+	})
+	if tok, err := client.VerifySessionCookie(context.Background(), cookie); err != nil {
+		t.Errorf("VerifySessionCookie() = (%v, %v); want = (token, nil)", tok, err)
+	}
+
+	cases := []struct {
+		name   string
+		cookie string
+	}{
+		{"OtherHostedDomain", getSessionCookie(mockIDTokenPayload{"hd": "other.com"})},
+		{"OtherEmailDomain", getSessionCookie(mockIDTokenPayload{
+			"email":          "alice@other.com",
+			"email_verified": true,
+			"firebase":       googleSignIn,
+		})},
+	}
+	for _, tc := range cases {
+		tok, err := client.VerifySessionCookie(context.Background(), tc.cookie)
+		if tok != nil || !IsHostedDomainMismatch(err) {
+			t.Errorf("VerifySessionCookie(%s) = (%v, %v); want = (nil, HostedDomainMismatch)", tc.name, tok, err)
+		}
+		want := `session cookie does not belong to the hosted domain "example.com"`
+		if err != nil && !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("VerifySessionCookie(%s) = %q; want prefix = %q", tc.name, err.Error(), want)
+		}
+	}
+}
+
 func TestVerifyIDTokenClockSkew(t *testing.T) {
 	now := testClock.Now().Unix()
 	cases := []struct {
@@ -526,7 +821,7 @@ func TestVerifyIDTokenClockSkew(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ft, err := client.VerifyIDToken(context.Background(), tc.token)
 			if err != nil {
-				t.Fatalf("VerifyIDToken(%q) = (%q, %v); want = (token, nil)", tc.name, ft, err)
+				t.Fatalf("VerifyIDToken(%q) = (%v, %v); want = (token, nil)", tc.name, ft, err)
 			}
 			if ft.Claims["admin"] != true {
 				t.Errorf("Claims['admin'] = %v; want = true", ft.Claims["admin"])
@@ -660,27 +955,37 @@ func TestVerifyIDTokenInvalidAlgorithm(t *testing.T) {
 	if err := decode(segments[1], &payload); err != nil {
 		t.Fatal(err)
 	}
-	info := &jwtInfo{
-		header: jwtHeader{
-			Algorithm: "HS256",
-			Type:      "JWT",
-			KeyID:     "mock-key-id-1",
-		},
-		payload: payload,
-	}
-	token, err := info.Token(context.Background(), testSigner)
-	if err != nil {
-		t.Fatal(err)
-	}
 
+	// Key lookup must never happen for tokens with an unsupported algorithm.
+	tv := *testIDTokenVerifier
+	tv.keySource = &mockKeySource{err: errors.New("unexpected key lookup")}
 	client := &Client{
 		baseClient: &baseClient{
-			idTokenVerifier: testIDTokenVerifier,
+			idTokenVerifier: &tv,
 		},
 	}
-	_, err = client.VerifyIDToken(context.Background(), token)
-	if !IsIDTokenInvalid(err) {
-		t.Errorf("VerifyIDToken(InvalidAlgorithm) = nil; want = IDTokenInvalid")
+
+	for _, alg := range []string{"none", "HS256", "RS512", "rs256", ""} {
+		t.Run(alg, func(t *testing.T) {
+			info := &jwtInfo{
+				header: jwtHeader{
+					Algorithm: alg,
+					Type:      "JWT",
+					KeyID:     "mock-key-id-1",
+				},
+				payload: payload,
+			}
+			token, err := info.Token(context.Background(), testSigner)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := fmt.Sprintf("ID token has unsupported signing algorithm; expected \"RS256\" but got %q", alg)
+			_, err = client.VerifyIDToken(context.Background(), token)
+			if !IsIDTokenInvalid(err) || !strings.HasPrefix(err.Error(), want) {
+				t.Errorf("VerifyIDToken(%q) = %v; want = %q", alg, err, want)
+			}
+		})
 	}
 }
 
@@ -708,8 +1013,9 @@ func TestVerifyIDTokenUnsigned(t *testing.T) {
 		},
 	}
 	_, err := client.VerifyIDToken(context.Background(), token)
-	if !IsIDTokenInvalid(err) {
-		t.Errorf("VerifyIDToken(Unsigned) = %v; want = IDTokenInvalid", err)
+	want := `ID token has unsupported signing algorithm; expected "RS256" but got "none"`
+	if !IsIDTokenInvalid(err) || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("VerifyIDToken(Unsigned) = %v; want = %q", err, want)
 	}
 }
 
@@ -803,7 +1109,7 @@ func TestCustomTokenVerification(t *testing.T) {
 }
 
 func TestCertificateRequestError(t *testing.T) {
-	tv, err := newIDTokenVerifier(context.Background(), testProjectID)
+	tv, err := newIDTokenVerifier(context.Background(), &internal.AuthConfig{ProjectID: testProjectID})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1256,7 +1562,7 @@ func signerForTests(ctx context.Context) (cryptoSigner, error) {
 }
 
 func idTokenVerifierForTests(ctx context.Context) (*tokenVerifier, error) {
-	tv, err := newIDTokenVerifier(ctx, testProjectID)
+	tv, err := newIDTokenVerifier(ctx, &internal.AuthConfig{ProjectID: testProjectID})
 	if err != nil {
 		return nil, err
 	}
@@ -1270,7 +1576,7 @@ func idTokenVerifierForTests(ctx context.Context) (*tokenVerifier, error) {
 }
 
 func cookieVerifierForTests(ctx context.Context) (*tokenVerifier, error) {
-	tv, err := newSessionCookieVerifier(ctx, testProjectID)
+	tv, err := newSessionCookieVerifier(ctx, &internal.AuthConfig{ProjectID: testProjectID})
 	if err != nil {
 		return nil, err
 	}
@@ -1285,7 +1591,7 @@ func cookieVerifierForTests(ctx context.Context) (*tokenVerifier, error) {
 
 // mockKeySource provides access to a set of in-memory public keys.
 type mockKeySource struct {
-	keys []*publicKey
+	keys []*internal.PublicKey
 	err  error
 }
 
@@ -1294,7 +1600,7 @@ func newMockKeySource(filePath string) (*mockKeySource, error) {
 	if err != nil {
 		return nil, err
 	}
-	keys, err := parsePublicKeys(certs)
+	keys, err := internal.ParseX509PublicKeys(certs)
 	if err != nil {
 		return nil, err
 	}
@@ -1303,7 +1609,7 @@ func newMockKeySource(filePath string) (*mockKeySource, error) {
 	}, nil
 }
 
-func (k *mockKeySource) Keys(ctx context.Context) ([]*publicKey, error) {
+func (k *mockKeySource) Keys(ctx context.Context) ([]*internal.PublicKey, error) {
 	return k.keys, k.err
 }
 
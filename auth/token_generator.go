@@ -175,6 +175,7 @@ func newIAMSigner(ctx context.Context, config *internal.AuthConfig) (*iamSigner,
 	if err != nil {
 		return nil, err
 	}
+	hc.Client = config.WrapTransport.Wrap(hc.Client)
 
 	return &iamSigner{
 		mutex:        &sync.Mutex{},
@@ -185,11 +186,11 @@ func newIAMSigner(ctx context.Context, config *internal.AuthConfig) (*iamSigner,
 	}, nil
 }
 
-func (s iamSigner) Algorithm() string {
+func (s *iamSigner) Algorithm() string {
 	return algorithmRS256
 }
 
-func (s iamSigner) Sign(ctx context.Context, b []byte) ([]byte, error) {
+func (s *iamSigner) Sign(ctx context.Context, b []byte) ([]byte, error) {
 	account, err := s.Email(ctx)
 	if err != nil {
 		return nil, err
@@ -214,13 +215,15 @@ func (s iamSigner) Sign(ctx context.Context, b []byte) ([]byte, error) {
 	return base64.StdEncoding.DecodeString(signResponse.Signature)
 }
 
-func (s iamSigner) Email(ctx context.Context) (string, error) {
+// Email returns the service account used for signing. When the service account is discovered via
+// the metadata service, the result is cached so that subsequent calls do not make further requests.
+func (s *iamSigner) Email(ctx context.Context) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	if s.serviceAcct != "" {
 		return s.serviceAcct, nil
 	}
 
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
 	result, err := s.callMetadataService(ctx)
 	if err != nil {
 		msg := "failed to determine service account: %v; initialize the SDK with service " +
@@ -234,7 +237,7 @@ func (s iamSigner) Email(ctx context.Context) (string, error) {
 	return result, nil
 }
 
-func (s iamSigner) callMetadataService(ctx context.Context) (string, error) {
+func (s *iamSigner) callMetadataService(ctx context.Context) (string, error) {
 	// Use the built-in default client without request authorization or retries for this call.
 	noAuthClient := &internal.HTTPClient{
 		Client: http.DefaultClient,
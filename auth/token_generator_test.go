@@ -218,7 +218,9 @@ func TestIAMSignerWithMetadataService(t *testing.T) {
 
 	// start mock metadata service and test Email()
 	serviceAcct := "discovered-service-account"
+	var metadataCalls int
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metadataCalls++
 		defer r.Body.Close()
 		flavor := r.Header.Get("Metadata-Flavor")
 		if flavor != "Google" {
@@ -248,6 +250,11 @@ func TestIAMSignerWithMetadataService(t *testing.T) {
 	if string(signature) != wantSignature {
 		t.Errorf("Sign() = %q; want = %q", string(signature), wantSignature)
 	}
+
+	// the discovered service account is cached
+	if metadataCalls != 1 {
+		t.Errorf("Metadata service calls = %d; want = 1", metadataCalls)
+	}
 }
 
 func TestIAMSignerNoMetadataService(t *testing.T) {
@@ -20,18 +20,12 @@ import (
 	"crypto"
 	"crypto/rsa"
 	"crypto/sha256"
-	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
-	"encoding/pem"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"strconv"
 	"strings"
-	"sync"
-	"time"
 
 	"firebase.google.com/go/v4/internal"
 	"google.golang.org/api/option"
@@ -98,44 +92,46 @@ type tokenVerifier struct {
 	issuerPrefix      string
 	invalidTokenCode  string
 	expiredTokenCode  string
-	keySource         keySource
+	keySource         internal.KeySource
 	clock             internal.Clock
 }
 
-func newIDTokenVerifier(ctx context.Context, projectID string) (*tokenVerifier, error) {
+func newIDTokenVerifier(ctx context.Context, conf *internal.AuthConfig) (*tokenVerifier, error) {
 	noAuthHTTPClient, _, err := transport.NewHTTPClient(ctx, option.WithoutAuthentication())
 	if err != nil {
 		return nil, err
 	}
+	noAuthHTTPClient = conf.WrapTransport.Wrap(noAuthHTTPClient)
 
 	return &tokenVerifier{
 		shortName:         "ID token",
 		articledShortName: "an ID token",
 		docURL:            "https://firebase.google.com/docs/auth/admin/verify-id-tokens",
-		projectID:         projectID,
+		projectID:         conf.ProjectID,
 		issuerPrefix:      idTokenIssuerPrefix,
 		invalidTokenCode:  idTokenInvalid,
 		expiredTokenCode:  idTokenExpired,
-		keySource:         newHTTPKeySource(idTokenCertURL, noAuthHTTPClient),
+		keySource:         internal.NewHTTPKeySource(idTokenCertURL, noAuthHTTPClient, internal.ParseX509PublicKeys),
 		clock:             internal.SystemClock,
 	}, nil
 }
 
-func newSessionCookieVerifier(ctx context.Context, projectID string) (*tokenVerifier, error) {
+func newSessionCookieVerifier(ctx context.Context, conf *internal.AuthConfig) (*tokenVerifier, error) {
 	noAuthHTTPClient, _, err := transport.NewHTTPClient(ctx, option.WithoutAuthentication())
 	if err != nil {
 		return nil, err
 	}
+	noAuthHTTPClient = conf.WrapTransport.Wrap(noAuthHTTPClient)
 
 	return &tokenVerifier{
 		shortName:         "session cookie",
 		articledShortName: "a session cookie",
 		docURL:            "https://firebase.google.com/docs/auth/admin/manage-cookies",
-		projectID:         projectID,
+		projectID:         conf.ProjectID,
 		issuerPrefix:      sessionCookieIssuerPrefix,
 		invalidTokenCode:  sessionCookieInvalid,
 		expiredTokenCode:  sessionCookieExpired,
-		keySource:         newHTTPKeySource(sessionCookieCertURL, noAuthHTTPClient),
+		keySource:         internal.NewHTTPKeySource(sessionCookieCertURL, noAuthHTTPClient, internal.ParseX509PublicKeys),
 		clock:             internal.SystemClock,
 	}, nil
 }
@@ -168,6 +164,7 @@ func (tv *tokenVerifier) VerifyToken(ctx context.Context, token string, isEmulat
 	if err := tv.verifyTimestamps(payload); err != nil {
 		return nil, err
 	}
+	payload.VerifiedAt = tv.clock.Now()
 
 	// In emulator mode, skip signature verification
 	if isEmulator {
@@ -262,6 +259,13 @@ func (tv *tokenVerifier) verifyHeaderAndBody(token string, isEmulator bool) (*To
 		return nil, err
 	}
 
+	// Reject unexpected algorithms (including "none" and HMAC variants) before looking at any
+	// other part of the token. Only the emulator issues unsigned tokens.
+	if !isEmulator && header.Algorithm != algorithmRS256 {
+		return nil, fmt.Errorf("%s has unsupported signing algorithm; expected %q but got %q",
+			tv.shortName, algorithmRS256, header.Algorithm)
+	}
+
 	if err := decode(segments[1], &payload); err != nil {
 		return nil, err
 	}
@@ -273,10 +277,6 @@ func (tv *tokenVerifier) verifyHeaderAndBody(token string, isEmulator bool) (*To
 		}
 		return nil, fmt.Errorf("%s has no 'kid' header", tv.shortName)
 	}
-	if !isEmulator && header.Algorithm != "RS256" {
-		return nil, fmt.Errorf("%s has invalid algorithm; expected 'RS256' but got %q",
-			tv.shortName, header.Algorithm)
-	}
 	if payload.Audience != tv.projectID {
 		return nil, fmt.Errorf("%s has invalid 'aud' (audience) claim; expected %q but got %q; %s",
 			tv.shortName, tv.projectID, payload.Audience, tv.getProjectIDMatchMessage())
@@ -303,11 +303,26 @@ func (tv *tokenVerifier) verifyHeaderAndBody(token string, isEmulator bool) (*To
 		delete(customClaims, standardClaim)
 	}
 	payload.Claims = customClaims
+	payload.EmailVerified = parseEmailVerified(customClaims["email_verified"])
 
 	return &payload, nil
 }
 
-func (tv *tokenVerifier) verifySignatureWithKeys(ctx context.Context, token string, keys []*publicKey) bool {
+// parseEmailVerified interprets the email_verified claim, which some identity providers encode as
+// a string instead of a boolean.
+func parseEmailVerified(v interface{}) bool {
+	switch ev := v.(type) {
+	case bool:
+		return ev
+	case string:
+		verified, _ := strconv.ParseBool(strings.TrimSpace(ev))
+		return verified
+	default:
+		return false
+	}
+}
+
+func (tv *tokenVerifier) verifySignatureWithKeys(ctx context.Context, token string, keys []*internal.PublicKey) bool {
 	segments := strings.Split(token, ".")
 	var h jwtHeader
 	decode(segments[0], &h)
@@ -340,7 +355,7 @@ func decode(segment string, i interface{}) error {
 	return json.NewDecoder(bytes.NewBuffer(decoded)).Decode(i)
 }
 
-func verifyJWTSignature(parts []string, k *publicKey) error {
+func verifyJWTSignature(parts []string, k *internal.PublicKey) error {
 	content := parts[0] + "." + parts[1]
 	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
 	if err != nil {
@@ -351,144 +366,3 @@ func verifyJWTSignature(parts []string, k *publicKey) error {
 	h.Write([]byte(content))
 	return rsa.VerifyPKCS1v15(k.Key, crypto.SHA256, h.Sum(nil), []byte(signature))
 }
-
-// publicKey represents a parsed RSA public key along with its unique key ID.
-type publicKey struct {
-	Kid string
-	Key *rsa.PublicKey
-}
-
-// keySource is used to obtain a set of public keys, which can be used to verify cryptographic
-// signatures.
-type keySource interface {
-	Keys(context.Context) ([]*publicKey, error)
-}
-
-// httpKeySource fetches RSA public keys from a remote HTTP server, and caches them in
-// memory. It also handles cache! invalidation and refresh based on the standard HTTP
-// cache-control headers.
-type httpKeySource struct {
-	KeyURI     string
-	HTTPClient *http.Client
-	CachedKeys []*publicKey
-	ExpiryTime time.Time
-	Clock      internal.Clock
-	Mutex      *sync.Mutex
-}
-
-func newHTTPKeySource(uri string, hc *http.Client) *httpKeySource {
-	return &httpKeySource{
-		KeyURI:     uri,
-		HTTPClient: hc,
-		Clock:      internal.SystemClock,
-		Mutex:      &sync.Mutex{},
-	}
-}
-
-// Keys returns the RSA Public Keys hosted at this key source's URI. Refreshes the data if
-// the cache is stale.
-func (k *httpKeySource) Keys(ctx context.Context) ([]*publicKey, error) {
-	k.Mutex.Lock()
-	defer k.Mutex.Unlock()
-	if len(k.CachedKeys) == 0 || k.hasExpired() {
-		err := k.refreshKeys(ctx)
-		if err != nil && len(k.CachedKeys) == 0 {
-			return nil, err
-		}
-	}
-	return k.CachedKeys, nil
-}
-
-// hasExpired indicates whether the cache has expired.
-func (k *httpKeySource) hasExpired() bool {
-	return k.Clock.Now().After(k.ExpiryTime)
-}
-
-func (k *httpKeySource) refreshKeys(ctx context.Context) error {
-	k.CachedKeys = nil
-	req, err := http.NewRequest(http.MethodGet, k.KeyURI, nil)
-	if err != nil {
-		return err
-	}
-
-	resp, err := k.HTTPClient.Do(req.WithContext(ctx))
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	contents, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("invalid response (%d) while retrieving public keys: %s",
-			resp.StatusCode, string(contents))
-	}
-
-	newKeys, err := parsePublicKeys(contents)
-	if err != nil {
-		return err
-	}
-
-	maxAge, err := findMaxAge(resp)
-	if err != nil {
-		return err
-	}
-
-	k.CachedKeys = append([]*publicKey(nil), newKeys...)
-	k.ExpiryTime = k.Clock.Now().Add(*maxAge)
-	return nil
-}
-
-func parsePublicKeys(keys []byte) ([]*publicKey, error) {
-	m := make(map[string]string)
-	err := json.Unmarshal(keys, &m)
-	if err != nil {
-		return nil, err
-	}
-
-	var result []*publicKey
-	for kid, key := range m {
-		pubKey, err := parsePublicKey(kid, []byte(key))
-		if err != nil {
-			return nil, err
-		}
-		result = append(result, pubKey)
-	}
-	return result, nil
-}
-
-func parsePublicKey(kid string, key []byte) (*publicKey, error) {
-	block, _ := pem.Decode(key)
-	if block == nil {
-		return nil, errors.New("failed to decode the certificate as PEM")
-	}
-	cert, err := x509.ParseCertificate(block.Bytes)
-	if err != nil {
-		return nil, err
-	}
-	pk, ok := cert.PublicKey.(*rsa.PublicKey)
-	if !ok {
-		return nil, errors.New("certificate is not an RSA key")
-	}
-	return &publicKey{kid, pk}, nil
-}
-
-func findMaxAge(resp *http.Response) (*time.Duration, error) {
-	cc := resp.Header.Get("cache-control")
-	for _, value := range strings.Split(cc, ",") {
-		value = strings.TrimSpace(value)
-		if strings.HasPrefix(value, "max-age=") {
-			sep := strings.Index(value, "=")
-			seconds, err := strconv.ParseInt(value[sep+1:], 10, 64)
-			if err != nil {
-				return nil, err
-			}
-			duration := time.Duration(seconds) * time.Second
-			return &duration, nil
-		}
-	}
-	return nil, errors.New("Could not find expiry time from HTTP headers")
-}
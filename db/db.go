@@ -20,21 +20,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
 	"runtime"
 	"strings"
 
 	"firebase.google.com/go/v4/internal"
+	"golang.org/x/oauth2"
 	"google.golang.org/api/option"
 )
 
 const userAgentFormat = "Firebase/HTTP/%s/%s/AdminGo"
 const invalidChars = "[].#$"
 const authVarOverride = "auth_variable_override"
+const emulatorHostEnvVar = "FIREBASE_DATABASE_EMULATOR_HOST"
+
+var emulatorToken = &oauth2.Token{
+	AccessToken: "owner",
+}
 
 // Client is the interface for the Firebase Realtime Database service.
 type Client struct {
 	hc           *internal.HTTPClient
 	url          string
+	namespace    string
 	authOverride string
 }
 
@@ -42,10 +50,29 @@ type Client struct {
 //
 // This function can only be invoked from within the SDK. Client applications should access the
 // Database service through firebase.App.
+//
+// When the FIREBASE_DATABASE_EMULATOR_HOST environment variable is set, the client connects to
+// the Realtime Database emulator running at that host instead. The emulator namespace is taken
+// from the ns query parameter of the database URL if present. Otherwise the URL must be a
+// firebaseio.com or firebasedatabase.app URL, and the namespace is taken from its subdomain. In
+// emulator mode the client authenticates as the emulator owner, and the client options of the
+// configuration (credentials, HTTP clients, endpoints and so on) are not used. A transport
+// wrapper set in the configuration still applies.
 func NewClient(ctx context.Context, c *internal.DatabaseConfig) (*Client, error) {
 	p, err := url.ParseRequestURI(c.URL)
 	if err != nil {
 		return nil, err
+	}
+
+	baseURL := fmt.Sprintf("https://%s", p.Host)
+	var namespace string
+	emulatorHost := os.Getenv(emulatorHostEnvVar)
+	if emulatorHost != "" {
+		namespace = emulatorNamespace(p)
+		if namespace == "" {
+			return nil, fmt.Errorf("invalid database URL: %q; failed to determine the emulator namespace", c.URL)
+		}
+		baseURL = fmt.Sprintf("http://%s", emulatorHost)
 	} else if p.Scheme != "https" {
 		return nil, fmt.Errorf("invalid database URL: %q; want scheme: %q", c.URL, "https")
 	}
@@ -59,21 +86,40 @@ func NewClient(ctx context.Context, c *internal.DatabaseConfig) (*Client, error)
 	}
 
 	opts := append([]option.ClientOption{}, c.Opts...)
+	if emulatorHost != "" {
+		opts = []option.ClientOption{option.WithTokenSource(oauth2.StaticTokenSource(emulatorToken))}
+	}
 	ua := fmt.Sprintf(userAgentFormat, c.Version, runtime.Version())
 	opts = append(opts, option.WithUserAgent(ua))
 	hc, _, err := internal.NewHTTPClient(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
+	hc.Client = c.WrapTransport.Wrap(hc.Client)
 
 	hc.CreateErrFn = handleRTDBError
 	return &Client{
 		hc:           hc,
-		url:          fmt.Sprintf("https://%s", p.Host),
+		url:          baseURL,
+		namespace:    namespace,
 		authOverride: string(ao),
 	}, nil
 }
 
+// emulatorNamespace returns the emulator namespace of the given database URL, or an empty string
+// if the namespace cannot be determined.
+func emulatorNamespace(p *url.URL) string {
+	if ns := p.Query().Get("ns"); ns != "" {
+		return ns
+	}
+
+	host := p.Hostname()
+	if strings.HasSuffix(host, ".firebaseio.com") || strings.HasSuffix(host, ".firebasedatabase.app") {
+		return strings.Split(host, ".")[0]
+	}
+	return ""
+}
+
 // NewRef returns a new database reference representing the node at the specified path.
 func (c *Client) NewRef(path string) *Ref {
 	segs := parsePath(path)
@@ -97,13 +143,24 @@ func (c *Client) sendAndUnmarshal(
 	}
 
 	req.URL = fmt.Sprintf("%s%s.json", c.url, req.URL)
-	if c.authOverride != "" {
-		req.Opts = append(req.Opts, internal.WithQueryParam(authVarOverride, c.authOverride))
-	}
+	req.Opts = append(req.Opts, c.queryParams()...)
 
 	return c.hc.DoAndUnmarshal(ctx, req, v)
 }
 
+// queryParams returns the query parameters that must be included in every request sent to the
+// database.
+func (c *Client) queryParams() []internal.HTTPOption {
+	var opts []internal.HTTPOption
+	if c.authOverride != "" {
+		opts = append(opts, internal.WithQueryParam(authVarOverride, c.authOverride))
+	}
+	if c.namespace != "" {
+		opts = append(opts, internal.WithQueryParam("ns", c.namespace))
+	}
+	return opts
+}
+
 func parsePath(path string) []string {
 	var segs []string
 	for _, s := range strings.Split(path, "/") {
@@ -173,6 +173,116 @@ func TestInvalidURL(t *testing.T) {
 	}
 }
 
+func TestNewClientEmulatorHostEnvVar(t *testing.T) {
+	os.Setenv(emulatorHostEnvVar, "localhost:9000")
+	defer os.Unsetenv(emulatorHostEnvVar)
+
+	cases := []struct {
+		url       string
+		namespace string
+	}{
+		{testURL, "test-db"},
+		{"http://localhost:9000?ns=other-db", "other-db"},
+		{"https://test-db.firebaseio.com?ns=other-db", "other-db"},
+		{"https://test-db-default-rtdb.europe-west1.firebasedatabase.app", "test-db-default-rtdb"},
+	}
+	for _, tc := range cases {
+		c, err := NewClient(context.Background(), &internal.DatabaseConfig{
+			Opts: testOpts,
+			URL:  tc.url,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.url != "http://localhost:9000" {
+			t.Errorf("NewClient(%q).url = %q; want = %q", tc.url, c.url, "http://localhost:9000")
+		}
+		if c.namespace != tc.namespace {
+			t.Errorf("NewClient(%q).namespace = %q; want = %q", tc.url, c.namespace, tc.namespace)
+		}
+	}
+
+	invalid := []string{
+		"http://localhost:9000",
+		"https://test-db.example.com",
+	}
+	for _, u := range invalid {
+		c, err := NewClient(context.Background(), &internal.DatabaseConfig{
+			Opts: testOpts,
+			URL:  u,
+		})
+		want := fmt.Sprintf("invalid database URL: %q; failed to determine the emulator namespace", u)
+		if c != nil || err == nil || err.Error() != want {
+			t.Errorf("NewClient(%q) = (%v, %v); want = (nil, %q)", u, c, err, want)
+		}
+	}
+}
+
+func TestEmulatorRequest(t *testing.T) {
+	os.Setenv(emulatorHostEnvVar, "localhost:9000")
+	defer os.Unsetenv(emulatorHostEnvVar)
+
+	c, err := NewClient(context.Background(), &internal.DatabaseConfig{
+		Opts:    testOpts,
+		URL:     testURL,
+		Version: "1.2.3",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &mockServer{Resp: "data"}
+	srv := mock.Start(c)
+	defer srv.Close()
+
+	var got string
+	if err := c.NewRef("peter").Get(context.Background(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != "data" {
+		t.Errorf("Get() = %q; want = %q", got, "data")
+	}
+
+	if len(mock.Reqs) != 1 {
+		t.Fatalf("Request Count = %d; want = 1", len(mock.Reqs))
+	}
+	req := mock.Reqs[0]
+	if h := req.Header.Get("Authorization"); h != "Bearer owner" {
+		t.Errorf("Authorization = %q; want = %q", h, "Bearer owner")
+	}
+	if req.Path != "/peter.json" {
+		t.Errorf("Path = %q; want = %q", req.Path, "/peter.json")
+	}
+	if ns := req.Query["ns"]; ns != "test-db" {
+		t.Errorf("QueryParam(ns) = %q; want = %q", ns, "test-db")
+	}
+}
+
+func TestEmulatorWrapTransport(t *testing.T) {
+	os.Setenv(emulatorHostEnvVar, "localhost:9000")
+	defer os.Unsetenv(emulatorHostEnvVar)
+
+	var wrapped int
+	c, err := NewClient(context.Background(), &internal.DatabaseConfig{
+		Opts: testOpts,
+		URL:  testURL,
+		WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+			wrapped++
+			return rt
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wrapped != 1 {
+		t.Errorf("WrapTransport() calls = %d; want = 1", wrapped)
+	}
+	if c.hc.Client.Transport == nil {
+		t.Errorf("Transport = nil; want = wrapped transport")
+	}
+}
+
 func TestInvalidAuthOverride(t *testing.T) {
 	c, err := NewClient(context.Background(), &internal.DatabaseConfig{
 		Opts:         testOpts,
@@ -0,0 +1,274 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+// Reconnect delays of the listeners. These are variables so that tests can shorten them.
+var (
+	minReconnectDelay = 1 * time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// EventType is the type of a change reported by a database listener.
+type EventType string
+
+const (
+	// EventPut indicates that the data at Event.Path has been replaced with Event.Data.
+	EventPut EventType = "put"
+
+	// EventPatch indicates that the children of Event.Path have been updated with the
+	// key-value pairs in Event.Data.
+	EventPatch EventType = "patch"
+)
+
+// Event represents a change to the data at a database location, as reported by Ref.Listen.
+//
+// Path is relative to the location of the Ref that is being listened to. Data contains the raw
+// JSON payload of the change, which can be decoded by calling Unmarshal.
+//
+// When a listener stops due to an unrecoverable error, a final Event with a non-nil Err is
+// delivered on the channel before it is closed. Type, Path and Data are not set in that case.
+type Event struct {
+	Type EventType
+	Path string
+	Data json.RawMessage
+	Err  error
+}
+
+// Unmarshal parses the data contained in the event, and stores the result in v.
+//
+// Data deserialization is performed using https://golang.org/pkg/encoding/json/#Unmarshal, and
+// therefore v has the same requirements as the json package.
+func (e *Event) Unmarshal(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// Listen starts listening for changes to the data at the current database location.
+//
+// Listen opens a streaming connection to the database using the Server-Sent Events protocol of
+// the Realtime Database REST API. The first Event received on the returned channel is always a
+// put event containing the current value of the location. Subsequent events describe changes as
+// they occur.
+//
+// If the connection drops, or the server revokes the credential used by the stream, the listener
+// reconnects automatically with a fresh access token. Failed reconnect attempts are retried with
+// exponential backoff when the server responds with a 5xx or 429 status, and once when it
+// responds with 401, which may be caused by a token that was being refreshed. Each successful
+// reconnect starts with a put event at path "/" containing a full snapshot of the location,
+// which replaces any state built from the earlier events. Changes that occurred while the
+// listener was disconnected are only reflected in that snapshot.
+//
+// The listener stops, and the channel is closed, when ctx is cancelled, when a reconnect attempt
+// fails with any other error status, or when the server cancels the stream (e.g. because the
+// Security Rules no longer permit reading the location). Errors that prevent the initial
+// connection are returned directly.
+func (r *Ref) Listen(ctx context.Context) (<-chan *Event, error) {
+	body, err := r.client.openStream(ctx, r.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *Event)
+	go r.client.listen(ctx, r.Path, body, events)
+	return events, nil
+}
+
+func (c *Client) openStream(ctx context.Context, path string) (io.ReadCloser, error) {
+	if strings.ContainsAny(path, invalidChars) {
+		return nil, fmt.Errorf("invalid path with illegal characters: %q", path)
+	}
+
+	hr, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s.json", c.url, path), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]internal.HTTPOption{}, c.hc.Opts...)
+	opts = append(opts, internal.WithHeader("Accept", "text/event-stream"))
+	opts = append(opts, c.queryParams()...)
+	for _, o := range opts {
+		o(hr)
+	}
+
+	resp, err := c.hc.Client.Do(hr.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		err = handleRTDBError(&internal.Response{
+			Status: resp.StatusCode,
+			Header: resp.Header,
+			Body:   b,
+		})
+		if fe, ok := err.(*internal.FirebaseError); ok {
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			fe.Response = resp
+		}
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// listen delivers the events read from body, and keeps reconnecting to the stream until ctx is
+// cancelled or an unrecoverable error occurs.
+func (c *Client) listen(ctx context.Context, path string, body io.ReadCloser, events chan<- *Event) {
+	defer close(events)
+
+	delay := minReconnectDelay
+	for {
+		err := readEvents(ctx, body, events)
+		body.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && err != errStreamInterrupted {
+			sendEvent(ctx, events, &Event{Err: err})
+			return
+		}
+
+		retriedUnauthorized := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+
+			body, err = c.openStream(ctx, path)
+			if err == nil {
+				delay = minReconnectDelay
+				break
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if fe, ok := err.(*internal.FirebaseError); ok && fe.Response != nil {
+				switch status := fe.Response.StatusCode; {
+				case status >= http.StatusInternalServerError, status == http.StatusTooManyRequests:
+				case status == http.StatusUnauthorized && !retriedUnauthorized:
+					retriedUnauthorized = true
+				default:
+					sendEvent(ctx, events, &Event{Err: err})
+					return
+				}
+			}
+
+			delay *= 2
+			if delay > maxReconnectDelay {
+				delay = maxReconnectDelay
+			}
+		}
+	}
+}
+
+var errStreamInterrupted = errors.New("event stream interrupted")
+
+// readEvents parses the Server-Sent Events from body, and delivers the put and patch events
+// on the events channel.
+//
+// It returns errStreamInterrupted when the stream should be re-established, and any other error
+// when the stream has been terminated by the server.
+func readEvents(ctx context.Context, body io.Reader, events chan<- *Event) error {
+	reader := bufio.NewReader(body)
+	var name string
+	var data []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return errStreamInterrupted
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if name == "" && len(data) == 0 {
+				continue
+			}
+			e, err := parseEvent(name, strings.Join(data, "\n"))
+			if err != nil {
+				return err
+			}
+			if e != nil && !sendEvent(ctx, events, e) {
+				return nil
+			}
+			name, data = "", nil
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+}
+
+// parseEvent converts a single Server-Sent Event into an Event. It returns nil for events that
+// do not need to be delivered to the listener.
+func parseEvent(name, data string) (*Event, error) {
+	switch name {
+	case string(EventPut), string(EventPatch):
+		var p struct {
+			Path string          `json:"path"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(data), &p); err != nil {
+			return nil, fmt.Errorf("error while parsing %s event: %v", name, err)
+		}
+		return &Event{
+			Type: EventType(name),
+			Path: p.Path,
+			Data: p.Data,
+		}, nil
+	case "keep-alive":
+		return nil, nil
+	case "auth_revoked":
+		// The access token used by the stream has expired. Reconnecting obtains a fresh token.
+		return nil, errStreamInterrupted
+	case "cancel":
+		return nil, errors.New("listener cancelled by the server; make sure the database " +
+			"rules allow reading this location")
+	default:
+		return nil, nil
+	}
+}
+
+func sendEvent(ctx context.Context, events chan<- *Event, e *Event) bool {
+	select {
+	case events <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/errorutils"
+)
+
+// streamServer is a mock database server that serves a scripted Server-Sent Events stream for
+// each incoming connection. Once the script for a connection is exhausted, the stream is kept
+// open until the client disconnects. Connections with a non-zero entry in Statuses are rejected
+// with that status instead.
+type streamServer struct {
+	Scripts  [][]string
+	Statuses []int
+
+	mu   sync.Mutex
+	Reqs []*testReq
+	srv  *httptest.Server
+}
+
+func (s *streamServer) Start(c *Client) *httptest.Server {
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr, _ := newTestReq(r)
+		s.mu.Lock()
+		idx := len(s.Reqs)
+		s.Reqs = append(s.Reqs, tr)
+		s.mu.Unlock()
+
+		if idx < len(s.Statuses) && s.Statuses[idx] != 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(s.Statuses[idx])
+			w.Write([]byte(`{"error": "test error"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if idx >= len(s.Scripts) {
+			<-r.Context().Done()
+			return
+		}
+
+		for _, e := range s.Scripts[idx] {
+			fmt.Fprint(w, e)
+			w.(http.Flusher).Flush()
+		}
+		<-r.Context().Done()
+	}))
+	c.url = s.srv.URL
+	return s.srv
+}
+
+func (s *streamServer) Requests() []*testReq {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*testReq(nil), s.Reqs...)
+}
+
+func sseEvent(name, data string) string {
+	return fmt.Sprintf("event: %s\ndata: %s\n\n", name, data)
+}
+
+func newListenTestClient(c *Client) *Client {
+	copy := *c
+	return &copy
+}
+
+// withShortReconnectDelay shortens the reconnect delays of the listeners for the duration of a
+// test.
+func withShortReconnectDelay(t *testing.T) {
+	min, max := minReconnectDelay, maxReconnectDelay
+	minReconnectDelay, maxReconnectDelay = time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() {
+		minReconnectDelay, maxReconnectDelay = min, max
+	})
+}
+
+func receiveEvent(t *testing.T, events <-chan *Event) *Event {
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed; want event")
+		}
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return nil
+}
+
+func checkEvent(t *testing.T, got *Event, typ EventType, path string, want interface{}) {
+	if got.Err != nil {
+		t.Fatalf("Event.Err = %v; want = nil", got.Err)
+	}
+	if got.Type != typ {
+		t.Errorf("Event.Type = %q; want = %q", got.Type, typ)
+	}
+	if got.Path != path {
+		t.Errorf("Event.Path = %q; want = %q", got.Path, path)
+	}
+
+	var data interface{}
+	if err := got.Unmarshal(&data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Event.Data = %v; want = %v", data, want)
+	}
+}
+
+func checkClosed(t *testing.T, events <-chan *Event) {
+	select {
+	case e, ok := <-events:
+		if ok {
+			t.Fatalf("Event = %v; want closed channel", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for events channel to close")
+	}
+}
+
+func TestListen(t *testing.T) {
+	mock := &streamServer{
+		Scripts: [][]string{{
+			sseEvent("put", `{"path": "/", "data": {"name": "Peter Parker", "age": 17}}`),
+			sseEvent("keep-alive", "null"),
+			sseEvent("patch", `{"path": "/", "data": {"age": 18}}`),
+			sseEvent("put", `{"path": "/name", "data": null}`),
+		}},
+	}
+	c := newListenTestClient(client)
+	srv := mock.Start(c)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := c.NewRef("peter").Listen(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkEvent(t, receiveEvent(t, events), EventPut, "/", map[string]interface{}{
+		"name": "Peter Parker",
+		"age":  float64(17),
+	})
+	checkEvent(t, receiveEvent(t, events), EventPatch, "/", map[string]interface{}{
+		"age": float64(18),
+	})
+	checkEvent(t, receiveEvent(t, events), EventPut, "/name", nil)
+
+	cancel()
+	checkClosed(t, events)
+	checkOnlyRequest(t, mock.Requests(), &testReq{
+		Method: "GET",
+		Path:   "/peter.json",
+		Header: http.Header{"Accept": []string{"text/event-stream"}},
+	})
+}
+
+func TestListenWithAuthOverride(t *testing.T) {
+	mock := &streamServer{
+		Scripts: [][]string{{
+			sseEvent("put", `{"path": "/", "data": "value"}`),
+		}},
+	}
+	c := newListenTestClient(aoClient)
+	srv := mock.Start(c)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := c.NewRef("peter").Listen(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkEvent(t, receiveEvent(t, events), EventPut, "/", "value")
+	checkOnlyRequest(t, mock.Requests(), &testReq{
+		Method: "GET",
+		Path:   "/peter.json",
+		Query:  map[string]string{"auth_variable_override": testAuthOverrides},
+	})
+}
+
+func TestListenReconnect(t *testing.T) {
+	withShortReconnectDelay(t)
+	mock := &streamServer{
+		Scripts: [][]string{
+			{
+				sseEvent("put", `{"path": "/", "data": 1}`),
+				sseEvent("auth_revoked", `"credential is no longer valid"`),
+			},
+			{
+				sseEvent("put", `{"path": "/", "data": 2}`),
+			},
+		},
+	}
+	c := newListenTestClient(client)
+	srv := mock.Start(c)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := c.NewRef("peter").Listen(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkEvent(t, receiveEvent(t, events), EventPut, "/", float64(1))
+	checkEvent(t, receiveEvent(t, events), EventPut, "/", float64(2))
+
+	want := &testReq{Method: "GET", Path: "/peter.json"}
+	checkAllRequests(t, mock.Requests(), []*testReq{want, want})
+}
+
+func TestListenReconnectRetry(t *testing.T) {
+	withShortReconnectDelay(t)
+	revoked := []string{
+		sseEvent("put", `{"path": "/", "data": 1}`),
+		sseEvent("auth_revoked", `"credential is no longer valid"`),
+	}
+	mock := &streamServer{
+		Scripts: [][]string{
+			revoked, nil, nil, nil,
+			{sseEvent("put", `{"path": "/", "data": 2}`)},
+		},
+		Statuses: []int{
+			0,
+			http.StatusTooManyRequests,
+			http.StatusUnauthorized,
+			http.StatusServiceUnavailable,
+		},
+	}
+	c := newListenTestClient(client)
+	srv := mock.Start(c)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := c.NewRef("peter").Listen(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkEvent(t, receiveEvent(t, events), EventPut, "/", float64(1))
+	checkEvent(t, receiveEvent(t, events), EventPut, "/", float64(2))
+	if reqs := mock.Requests(); len(reqs) != 5 {
+		t.Errorf("Requests = %d; want = 5", len(reqs))
+	}
+}
+
+func TestListenReconnectError(t *testing.T) {
+	withShortReconnectDelay(t)
+	revoked := []string{
+		sseEvent("put", `{"path": "/", "data": 1}`),
+		sseEvent("auth_revoked", `"credential is no longer valid"`),
+	}
+	cases := []struct {
+		name     string
+		statuses []int
+		want     string
+	}{
+		{
+			"Unauthorized",
+			[]int{0, http.StatusUnauthorized, http.StatusUnauthorized},
+			"http error status: 401; reason: test error",
+		},
+		{
+			"PermissionDenied",
+			[]int{0, http.StatusForbidden},
+			"http error status: 403; reason: test error",
+		},
+	}
+
+	for _, tc := range cases {
+		mock := &streamServer{
+			Scripts:  [][]string{revoked},
+			Statuses: tc.statuses,
+		}
+		c := newListenTestClient(client)
+		srv := mock.Start(c)
+
+		events, err := c.NewRef("peter").Listen(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkEvent(t, receiveEvent(t, events), EventPut, "/", float64(1))
+		e := receiveEvent(t, events)
+		if e.Err == nil || e.Err.Error() != tc.want {
+			t.Errorf("Event.Err(%s) = %v; want = %q", tc.name, e.Err, tc.want)
+		}
+		checkClosed(t, events)
+		if reqs := mock.Requests(); len(reqs) != len(tc.statuses) {
+			t.Errorf("Requests(%s) = %d; want = %d", tc.name, len(reqs), len(tc.statuses))
+		}
+		srv.Close()
+	}
+}
+
+func TestListenCancelledByServer(t *testing.T) {
+	mock := &streamServer{
+		Scripts: [][]string{{
+			sseEvent("put", `{"path": "/", "data": 1}`),
+			sseEvent("cancel", "null"),
+		}},
+	}
+	c := newListenTestClient(client)
+	srv := mock.Start(c)
+	defer srv.Close()
+
+	events, err := c.NewRef("peter").Listen(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkEvent(t, receiveEvent(t, events), EventPut, "/", float64(1))
+	e := receiveEvent(t, events)
+	want := "listener cancelled by the server; make sure the database rules allow reading this location"
+	if e.Err == nil || e.Err.Error() != want {
+		t.Errorf("Event.Err = %v; want = %q", e.Err, want)
+	}
+	checkClosed(t, events)
+}
+
+func TestListenMalformedEvent(t *testing.T) {
+	mock := &streamServer{
+		Scripts: [][]string{{
+			sseEvent("put", `not json`),
+		}},
+	}
+	c := newListenTestClient(client)
+	srv := mock.Start(c)
+	defer srv.Close()
+
+	events, err := c.NewRef("peter").Listen(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := receiveEvent(t, events)
+	if e.Err == nil {
+		t.Errorf("Event.Err = nil; want error")
+	}
+	checkClosed(t, events)
+}
+
+func TestListenError(t *testing.T) {
+	mock := &mockServer{
+		Resp:   map[string]string{"error": "test error"},
+		Status: http.StatusUnauthorized,
+	}
+	srv := mock.Start(client)
+	defer srv.Close()
+
+	events, err := testref.Listen(context.Background())
+	want := "http error status: 401; reason: test error"
+	if events != nil || err == nil || err.Error() != want {
+		t.Errorf("Listen() = (%v, %v); want = (nil, %q)", events, err, want)
+	}
+	if !errorutils.IsUnauthenticated(err) {
+		t.Errorf("Listen() = %v; want = Unauthenticated", err)
+	}
+	if resp := errorutils.HTTPResponse(err); resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("HTTPResponse() = %v; want status = %d", resp, http.StatusUnauthorized)
+	}
+}
+
+func TestListenInvalidPath(t *testing.T) {
+	events, err := client.NewRef("foo$bar").Listen(context.Background())
+	if events != nil || err == nil {
+		t.Errorf("Listen() = (%v, %v); want = (nil, error)", events, err)
+	}
+}
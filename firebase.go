@@ -22,14 +22,18 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"net/http"
 	"os"
 
 	"cloud.google.com/go/firestore"
+	"firebase.google.com/go/v4/appcheck"
 	"firebase.google.com/go/v4/auth"
 	"firebase.google.com/go/v4/db"
 	"firebase.google.com/go/v4/iid"
 	"firebase.google.com/go/v4/internal"
 	"firebase.google.com/go/v4/messaging"
+	"firebase.google.com/go/v4/projectmanagement"
+	"firebase.google.com/go/v4/remoteconfig"
 	"firebase.google.com/go/v4/storage"
 	"google.golang.org/api/option"
 	"google.golang.org/api/transport"
@@ -50,6 +54,11 @@ type App struct {
 	projectID        string
 	serviceAccountID string
 	storageBucket    string
+	authEndpoint     string
+	iidEndpoint      string
+	pmEndpoint       string
+	rcEndpoint       string
+	wrapTransport    internal.TransportWrapper
 	opts             []option.ClientOption
 }
 
@@ -60,6 +69,42 @@ type Config struct {
 	ProjectID        string                  `json:"projectId"`
 	ServiceAccountID string                  `json:"serviceAccountId"`
 	StorageBucket    string                  `json:"storageBucket"`
+
+	// AuthEndpoint, InstanceIDEndpoint, ProjectManagementEndpoint and RemoteConfigEndpoint
+	// override the base URLs of the corresponding services, e.g. to send their requests to a
+	// reverse proxy or a regional gateway. Each value must be a scheme and host, such as
+	// "https://gateway.example.com:8443", optionally followed by a path prefix. It must not
+	// include the API version of the service, which the SDK appends to the base URL. An empty
+	// value selects the default endpoint.
+	//
+	// The Cloud Messaging endpoint is configured by passing option.WithEndpoint to NewApp. The
+	// services listed above do not read that option.
+	//
+	// These fields do not configure a forward (egress) proxy. By default the App clients honor
+	// the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables, like any Go program that
+	// uses http.DefaultTransport. To set a proxy in code, pass an HTTP client whose transport
+	// sets http.Transport.Proxy to NewApp via option.WithHTTPClient.
+	AuthEndpoint              string `json:"-"`
+	InstanceIDEndpoint        string `json:"-"`
+	ProjectManagementEndpoint string `json:"-"`
+	RemoteConfigEndpoint      string `json:"-"`
+
+	// WrapTransport, when set, is called with the HTTP transport of each client created by the
+	// App, and the returned RoundTripper is used in its place. This makes it possible to add
+	// tracing, metrics or logging to the requests made by the Auth, Database, Instance ID,
+	// Messaging, Project Management, Remote Config and App Check clients, including public key
+	// fetches and requests sent to emulators. The Firestore and Cloud Storage clients are
+	// created by the Google Cloud client libraries, and are not affected by this setting.
+	WrapTransport func(http.RoundTripper) http.RoundTripper `json:"-"`
+}
+
+// AppCheck returns an instance of appcheck.Client.
+func (a *App) AppCheck(ctx context.Context) (*appcheck.Client, error) {
+	conf := &internal.AppCheckConfig{
+		ProjectID:     a.projectID,
+		WrapTransport: a.wrapTransport,
+	}
+	return appcheck.NewClient(ctx, conf)
 }
 
 // Auth returns an instance of auth.Client.
@@ -69,6 +114,8 @@ func (a *App) Auth(ctx context.Context) (*auth.Client, error) {
 		Opts:             a.opts,
 		ServiceAccountID: a.serviceAccountID,
 		Version:          Version,
+		Endpoint:         a.authEndpoint,
+		WrapTransport:    a.wrapTransport,
 	}
 	return auth.NewClient(ctx, conf)
 }
@@ -83,10 +130,11 @@ func (a *App) Database(ctx context.Context) (*db.Client, error) {
 // identified by the given URL.
 func (a *App) DatabaseWithURL(ctx context.Context, url string) (*db.Client, error) {
 	conf := &internal.DatabaseConfig{
-		AuthOverride: a.authOverride,
-		URL:          url,
-		Opts:         a.opts,
-		Version:      Version,
+		AuthOverride:  a.authOverride,
+		URL:           url,
+		Opts:          a.opts,
+		Version:       Version,
+		WrapTransport: a.wrapTransport,
 	}
 	return db.NewClient(ctx, conf)
 }
@@ -112,8 +160,10 @@ func (a *App) Firestore(ctx context.Context) (*firestore.Client, error) {
 // InstanceID returns an instance of iid.Client.
 func (a *App) InstanceID(ctx context.Context) (*iid.Client, error) {
 	conf := &internal.InstanceIDConfig{
-		ProjectID: a.projectID,
-		Opts:      a.opts,
+		ProjectID:     a.projectID,
+		Opts:          a.opts,
+		Endpoint:      a.iidEndpoint,
+		WrapTransport: a.wrapTransport,
 	}
 	return iid.NewClient(ctx, conf)
 }
@@ -121,13 +171,38 @@ func (a *App) InstanceID(ctx context.Context) (*iid.Client, error) {
 // Messaging returns an instance of messaging.Client.
 func (a *App) Messaging(ctx context.Context) (*messaging.Client, error) {
 	conf := &internal.MessagingConfig{
-		ProjectID: a.projectID,
-		Opts:      a.opts,
-		Version:   Version,
+		ProjectID:     a.projectID,
+		Opts:          a.opts,
+		Version:       Version,
+		WrapTransport: a.wrapTransport,
 	}
 	return messaging.NewClient(ctx, conf)
 }
 
+// ProjectManagement returns an instance of projectmanagement.Client.
+func (a *App) ProjectManagement(ctx context.Context) (*projectmanagement.Client, error) {
+	conf := &internal.ProjectManagementConfig{
+		ProjectID:     a.projectID,
+		Opts:          a.opts,
+		Version:       Version,
+		Endpoint:      a.pmEndpoint,
+		WrapTransport: a.wrapTransport,
+	}
+	return projectmanagement.NewClient(ctx, conf)
+}
+
+// RemoteConfig returns an instance of remoteconfig.Client.
+func (a *App) RemoteConfig(ctx context.Context) (*remoteconfig.Client, error) {
+	conf := &internal.RemoteConfigConfig{
+		ProjectID:     a.projectID,
+		Opts:          a.opts,
+		Version:       Version,
+		Endpoint:      a.rcEndpoint,
+		WrapTransport: a.wrapTransport,
+	}
+	return remoteconfig.NewClient(ctx, conf)
+}
+
 // NewApp creates a new App from the provided config and client options.
 //
 // If the client options contain a valid credential (a service account file, a refresh token
@@ -158,6 +233,11 @@ func NewApp(ctx context.Context, config *Config, opts ...option.ClientOption) (*
 		projectID:        pid,
 		serviceAccountID: config.ServiceAccountID,
 		storageBucket:    config.StorageBucket,
+		authEndpoint:     config.AuthEndpoint,
+		iidEndpoint:      config.InstanceIDEndpoint,
+		pmEndpoint:       config.ProjectManagementEndpoint,
+		rcEndpoint:       config.RemoteConfigEndpoint,
+		wrapTransport:    config.WrapTransport,
 		opts:             o,
 	}, nil
 }
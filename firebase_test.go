@@ -22,6 +22,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"reflect"
 	"strconv"
@@ -338,6 +339,18 @@ func TestFirestoreWithNoProjectID(t *testing.T) {
 	}
 }
 
+func TestAppCheck(t *testing.T) {
+	ctx := context.Background()
+	app, err := NewApp(ctx, nil, option.WithCredentialsFile("testdata/service_account.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c, err := app.AppCheck(ctx); c == nil || err != nil {
+		t.Errorf("AppCheck() = (%v, %v); want (appcheck, nil)", c, err)
+	}
+}
+
 func TestInstanceID(t *testing.T) {
 	ctx := context.Background()
 	app, err := NewApp(ctx, nil, option.WithCredentialsFile("testdata/service_account.json"))
@@ -362,6 +375,30 @@ func TestMessaging(t *testing.T) {
 	}
 }
 
+func TestProjectManagement(t *testing.T) {
+	ctx := context.Background()
+	app, err := NewApp(ctx, nil, option.WithCredentialsFile("testdata/service_account.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c, err := app.ProjectManagement(ctx); c == nil || err != nil {
+		t.Errorf("ProjectManagement() = (%v, %v); want (projectmanagement, nil)", c, err)
+	}
+}
+
+func TestRemoteConfig(t *testing.T) {
+	ctx := context.Background()
+	app, err := NewApp(ctx, nil, option.WithCredentialsFile("testdata/service_account.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c, err := app.RemoteConfig(ctx); c == nil || err != nil {
+		t.Errorf("RemoteConfig() = (%v, %v); want (remoteconfig, nil)", c, err)
+	}
+}
+
 func TestMessagingSendWithCustomEndpoint(t *testing.T) {
 	name := "custom-endpoint-ok"
 
@@ -398,6 +435,293 @@ func TestMessagingSendWithCustomEndpoint(t *testing.T) {
 	}
 }
 
+func TestServiceEndpoints(t *testing.T) {
+	getUser := func(ctx context.Context, app *App) error {
+		c, err := app.Auth(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = c.GetUser(ctx, "test")
+		return err
+	}
+	deleteInstanceID := func(ctx context.Context, app *App) error {
+		c, err := app.InstanceID(ctx)
+		if err != nil {
+			return err
+		}
+		return c.DeleteInstanceID(ctx, "test-iid")
+	}
+	androidApp := func(ctx context.Context, app *App) error {
+		c, err := app.ProjectManagement(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = c.AndroidApp(ctx, "test-app")
+		return err
+	}
+	getTemplate := func(ctx context.Context, app *App) error {
+		c, err := app.RemoteConfig(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = c.GetTemplate(ctx)
+		return err
+	}
+
+	const (
+		lookupPath   = "/v1/projects/test-project-id/accounts:lookup"
+		iidPath      = "/v1/project/test-project-id/instanceId/test-iid"
+		appPath      = "/v1beta1/projects/-/androidApps/test-app"
+		templatePath = "/v1/projects/test-project-id/remoteConfig"
+	)
+	cases := []struct {
+		name   string
+		config *Config
+		call   func(context.Context, *App) error
+		body   string
+		want   string
+	}{
+		{
+			name:   "default auth",
+			config: &Config{ProjectID: "test-project-id"},
+			call:   getUser,
+			body:   `{"users": [{"localId": "test"}]}`,
+			want:   "https://identitytoolkit.googleapis.com" + lookupPath,
+		},
+		{
+			name:   "auth endpoint",
+			config: &Config{ProjectID: "test-project-id", AuthEndpoint: "https://auth.example.com"},
+			call:   getUser,
+			body:   `{"users": [{"localId": "test"}]}`,
+			want:   "https://auth.example.com" + lookupPath,
+		},
+		{
+			name:   "default instance id",
+			config: &Config{ProjectID: "test-project-id"},
+			call:   deleteInstanceID,
+			want:   "https://console.firebase.google.com" + iidPath,
+		},
+		{
+			name: "instance id endpoint",
+			config: &Config{
+				ProjectID:          "test-project-id",
+				InstanceIDEndpoint: "https://iid.example.com/prefix/",
+			},
+			call: deleteInstanceID,
+			want: "https://iid.example.com/prefix" + iidPath,
+		},
+		{
+			name:   "default project management",
+			config: &Config{ProjectID: "test-project-id"},
+			call:   androidApp,
+			want:   "https://firebase.googleapis.com" + appPath,
+		},
+		{
+			name: "project management endpoint",
+			config: &Config{
+				ProjectID:                 "test-project-id",
+				ProjectManagementEndpoint: "https://pm.example.com",
+			},
+			call: androidApp,
+			want: "https://pm.example.com" + appPath,
+		},
+		{
+			name:   "default remote config",
+			config: &Config{ProjectID: "test-project-id"},
+			call:   getTemplate,
+			want:   "https://firebaseremoteconfig.googleapis.com" + templatePath,
+		},
+		{
+			name: "remote config endpoint",
+			config: &Config{
+				ProjectID:            "test-project-id",
+				RemoteConfigEndpoint: "https://rc.example.com",
+			},
+			call: getTemplate,
+			want: "https://rc.example.com" + templatePath,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range cases {
+		body := tc.body
+		if body == "" {
+			body = "{}"
+		}
+		st := &stubTransport{body: body}
+		tc.config.WrapTransport = func(http.RoundTripper) http.RoundTripper {
+			return st
+		}
+		// The messaging endpoint must not affect the other services.
+		app, err := NewApp(
+			ctx,
+			tc.config,
+			option.WithTokenSource(&testTokenSource{AccessToken: "mock-token"}),
+			option.WithEndpoint("https://fcm.example.com"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := tc.call(ctx, app); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+
+		if len(st.urls) != 1 || st.urls[0] != tc.want {
+			t.Errorf("%s: sent requests to %v; want = [%s]", tc.name, st.urls, tc.want)
+		}
+	}
+}
+
+// stubTransport records the URLs of the outgoing requests, and responds to each of them with
+// the given JSON body without sending them.
+type stubTransport struct {
+	urls []string
+	body string
+}
+
+func (st *stubTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	st.urls = append(st.urls, r.URL.String())
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(st.body)),
+		Request:    r,
+	}, nil
+}
+
+type recordingTransport struct {
+	base http.RoundTripper
+	reqs []*http.Request
+}
+
+func (rt *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt.reqs = append(rt.reqs, r)
+	return rt.base.RoundTrip(r)
+}
+
+func TestWrapTransport(t *testing.T) {
+	var auth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "test"}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	var rt *recordingTransport
+	config := &Config{
+		ProjectID: "test-project-id",
+		WrapTransport: func(base http.RoundTripper) http.RoundTripper {
+			rt = &recordingTransport{base: base}
+			return rt
+		},
+	}
+	app, err := NewApp(
+		ctx,
+		config,
+		option.WithTokenSource(&testTokenSource{AccessToken: "mock-token"}),
+		option.WithEndpoint(ts.URL),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := app.Messaging(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Send(ctx, &messaging.Message{Token: "token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if rt == nil || len(rt.reqs) != 1 {
+		t.Fatalf("WrapTransport() did not intercept the request")
+	}
+	if !strings.HasPrefix(rt.reqs[0].URL.String(), ts.URL) {
+		t.Errorf("Request URL = %q; want prefix = %q", rt.reqs[0].URL.String(), ts.URL)
+	}
+	if auth != "Bearer mock-token" {
+		t.Errorf("Authorization = %q; want = %q", auth, "Bearer mock-token")
+	}
+}
+
+func TestWrapTransportDatabase(t *testing.T) {
+	var ua string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ua = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`"test"`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	var rt *redirectTransport
+	config := &Config{
+		WrapTransport: func(base http.RoundTripper) http.RoundTripper {
+			rt = &redirectTransport{base: base, url: ts.URL}
+			return rt
+		},
+	}
+	app, err := NewApp(ctx, config, option.WithTokenSource(&testTokenSource{AccessToken: "mock-token"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := app.DatabaseWithURL(ctx, "https://test-db.firebaseio.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got string
+	if err := c.NewRef("test").Get(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if rt == nil || rt.count != 1 {
+		t.Fatalf("WrapTransport() did not intercept the request")
+	}
+	if want := "Firebase/HTTP/" + Version; !strings.HasPrefix(ua, want) {
+		t.Errorf("User-Agent = %q; want prefix = %q", ua, want)
+	}
+}
+
+func TestWrapTransportFirestore(t *testing.T) {
+	ctx := context.Background()
+	config := &Config{
+		ProjectID: "test-project-id",
+		WrapTransport: func(base http.RoundTripper) http.RoundTripper {
+			return &recordingTransport{base: base}
+		},
+	}
+	app, err := NewApp(ctx, config, option.WithCredentialsFile("testdata/service_account.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c, err := app.Firestore(ctx); c == nil || err != nil {
+		t.Errorf("Firestore() = (%v, %v); want (firestore, nil)", c, err)
+	}
+}
+
+// redirectTransport sends all requests to the server at the given URL.
+type redirectTransport struct {
+	base  http.RoundTripper
+	url   string
+	count int
+}
+
+func (rt *redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt.count++
+	u, err := url.Parse(rt.url)
+	if err != nil {
+		return nil, err
+	}
+	r2 := r.Clone(r.Context())
+	r2.URL.Scheme, r2.URL.Host, r2.Host = u.Scheme, u.Host, u.Host
+	return rt.base.RoundTrip(r2)
+}
+
 func TestCustomTokenSource(t *testing.T) {
 	ctx := context.Background()
 	ts := &testTokenSource{AccessToken: "mock-token-from-custom"}
@@ -26,7 +26,7 @@ import (
 	"firebase.google.com/go/v4/internal"
 )
 
-const iidEndpoint = "https://console.firebase.google.com/v1"
+const defaultIIDURL = "https://console.firebase.google.com"
 
 var errorMessages = map[int]string{
 	http.StatusBadRequest:          "malformed instance id argument",
@@ -119,10 +119,16 @@ func NewClient(ctx context.Context, c *internal.InstanceIDConfig) (*Client, erro
 	if err != nil {
 		return nil, err
 	}
+	hc.Client = c.WrapTransport.Wrap(hc.Client)
+
+	baseURL := defaultIIDURL
+	if c.Endpoint != "" {
+		baseURL = strings.TrimSuffix(c.Endpoint, "/")
+	}
 
 	hc.CreateErrFn = createError
 	return &Client{
-		endpoint: iidEndpoint,
+		endpoint: baseURL + "/v1",
 		client:   hc,
 		project:  c.ProjectID,
 	}, nil
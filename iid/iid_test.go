@@ -40,6 +40,21 @@ func TestNoProjectID(t *testing.T) {
 	}
 }
 
+func TestNewClientWithEndpoint(t *testing.T) {
+	conf := &internal.InstanceIDConfig{
+		ProjectID: "test-project",
+		Opts:      testIIDConfig.Opts,
+		Endpoint:  "https://iid.example.com/",
+	}
+	client, err := NewClient(context.Background(), conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.endpoint != "https://iid.example.com/v1" {
+		t.Errorf("endpoint = %q; want = %q", client.endpoint, "https://iid.example.com/v1")
+	}
+}
+
 func TestInvalidInstanceID(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewClient(ctx, testIIDConfig)
@@ -52,6 +52,26 @@ type SuccessFn func(r *Response) bool
 // CreateErrFn is a function that creates an error from a given Response.
 type CreateErrFn func(r *Response) error
 
+// TransportWrapper wraps the transport of an HTTP client, so that outgoing requests can be
+// intercepted for tracing, metrics or logging.
+type TransportWrapper func(http.RoundTripper) http.RoundTripper
+
+// Wrap returns a copy of the given client with its transport wrapped. If the TransportWrapper is
+// nil, Wrap returns the given client unchanged.
+func (w TransportWrapper) Wrap(hc *http.Client) *http.Client {
+	if w == nil {
+		return hc
+	}
+
+	rt := hc.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	wrapped := *hc
+	wrapped.Transport = w(rt)
+	return &wrapped
+}
+
 // NewHTTPClient creates a new HTTPClient using the provided client options and the default
 // RetryConfig.
 //
@@ -180,6 +180,32 @@ func TestHTTPClient(t *testing.T) {
 	}
 }
 
+func TestTransportWrapper(t *testing.T) {
+	hc := &http.Client{Timeout: time.Second}
+	if got := TransportWrapper(nil).Wrap(hc); got != hc {
+		t.Errorf("Wrap(nil) = %v; want = %v", got, hc)
+	}
+
+	var base http.RoundTripper
+	wrapper := TransportWrapper(func(rt http.RoundTripper) http.RoundTripper {
+		base = rt
+		return &faultyTransport{}
+	})
+	got := wrapper.Wrap(hc)
+	if got == hc || hc.Transport != nil {
+		t.Errorf("Wrap() modified the original client")
+	}
+	if _, ok := got.Transport.(*faultyTransport); !ok {
+		t.Errorf("Wrap().Transport = %T; want = *faultyTransport", got.Transport)
+	}
+	if got.Timeout != time.Second {
+		t.Errorf("Wrap().Timeout = %v; want = %v", got.Timeout, time.Second)
+	}
+	if base != http.DefaultTransport {
+		t.Errorf("Wrapped transport = %v; want = http.DefaultTransport", base)
+	}
+}
+
 func TestDefaultOpts(t *testing.T) {
 	var header string
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
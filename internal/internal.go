@@ -35,12 +35,20 @@ var FirebaseScopes = []string{
 // SystemClock is a clock that returns local time of the system.
 var SystemClock = &systemClock{}
 
+// AppCheckConfig represents the configuration of Firebase App Check service.
+type AppCheckConfig struct {
+	ProjectID     string
+	WrapTransport TransportWrapper
+}
+
 // AuthConfig represents the configuration of Firebase Auth service.
 type AuthConfig struct {
 	Opts             []option.ClientOption
 	ProjectID        string
 	ServiceAccountID string
 	Version          string
+	WrapTransport    TransportWrapper
+	Endpoint         string
 }
 
 // HashConfig represents a hash algorithm configuration used to generate password hashes.
@@ -48,16 +56,19 @@ type HashConfig map[string]interface{}
 
 // InstanceIDConfig represents the configuration of Firebase Instance ID service.
 type InstanceIDConfig struct {
-	Opts      []option.ClientOption
-	ProjectID string
+	Opts          []option.ClientOption
+	ProjectID     string
+	WrapTransport TransportWrapper
+	Endpoint      string
 }
 
 // DatabaseConfig represents the configuration of Firebase Database service.
 type DatabaseConfig struct {
-	Opts         []option.ClientOption
-	URL          string
-	Version      string
-	AuthOverride map[string]interface{}
+	Opts          []option.ClientOption
+	URL           string
+	Version       string
+	AuthOverride  map[string]interface{}
+	WrapTransport TransportWrapper
 }
 
 // StorageConfig represents the configuration of Google Cloud Storage service.
@@ -68,9 +79,28 @@ type StorageConfig struct {
 
 // MessagingConfig represents the configuration of Firebase Cloud Messaging service.
 type MessagingConfig struct {
-	Opts      []option.ClientOption
-	ProjectID string
-	Version   string
+	Opts          []option.ClientOption
+	ProjectID     string
+	Version       string
+	WrapTransport TransportWrapper
+}
+
+// ProjectManagementConfig represents the configuration of Firebase Project Management service.
+type ProjectManagementConfig struct {
+	Opts          []option.ClientOption
+	ProjectID     string
+	Version       string
+	WrapTransport TransportWrapper
+	Endpoint      string
+}
+
+// RemoteConfigConfig represents the configuration of Firebase Remote Config service.
+type RemoteConfigConfig struct {
+	Opts          []option.ClientOption
+	ProjectID     string
+	Version       string
+	WrapTransport TransportWrapper
+	Endpoint      string
 }
 
 // MockTokenSource is a TokenSource implementation that can be used for testing.
@@ -0,0 +1,274 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PublicKey represents a parsed RSA public key along with its unique key ID.
+type PublicKey struct {
+	Kid string
+	Key *rsa.PublicKey
+}
+
+// KeySource is used to obtain a set of public keys, which can be used to verify cryptographic
+// signatures.
+type KeySource interface {
+	Keys(context.Context) ([]*PublicKey, error)
+}
+
+// KeyParser parses the body of a public key server response into a set of public keys.
+type KeyParser func([]byte) ([]*PublicKey, error)
+
+// HTTPKeySource fetches RSA public keys from a remote HTTP server, and caches them in memory.
+// It also handles cache invalidation and refresh based on the standard HTTP cache-control
+// headers.
+//
+// HTTPKeySource is safe for concurrent use. When the cache is stale, concurrent callers share a
+// single refresh request instead of each fetching the keys.
+type HTTPKeySource struct {
+	KeyURI     string
+	HTTPClient *http.Client
+	Parser     KeyParser
+	Clock      Clock
+
+	// DefaultMaxAge is how long the keys are cached when the server response does not specify a
+	// max-age. If zero, such responses are treated as errors.
+	DefaultMaxAge time.Duration
+
+	mutex      sync.Mutex
+	cachedKeys []*PublicKey
+	expiryTime time.Time
+	refresh    *keyRefresh
+}
+
+// keyRefresh is an in-flight request for fetching public keys.
+type keyRefresh struct {
+	done chan struct{}
+	keys []*PublicKey
+	err  error
+
+	// cancelled is set when the refresh failed because the context of the caller that started it
+	// was done. Other callers retry such refreshes instead of sharing the error.
+	cancelled bool
+}
+
+// NewHTTPKeySource creates a new HTTPKeySource that fetches keys from the given URI, and parses
+// them with the given KeyParser.
+func NewHTTPKeySource(uri string, hc *http.Client, parser KeyParser) *HTTPKeySource {
+	return &HTTPKeySource{
+		KeyURI:     uri,
+		HTTPClient: hc,
+		Parser:     parser,
+		Clock:      SystemClock,
+	}
+}
+
+// Keys returns the RSA Public Keys hosted at this key source's URI. Refreshes the data if
+// the cache is stale.
+//
+// The keys are fetched using the context of the caller that starts a refresh. If that context is
+// done before the refresh completes, concurrent callers waiting on the same refresh start a new
+// one instead of failing with the context error.
+func (k *HTTPKeySource) Keys(ctx context.Context) ([]*PublicKey, error) {
+	for {
+		k.mutex.Lock()
+		if len(k.cachedKeys) > 0 && !k.Clock.Now().After(k.expiryTime) {
+			keys := k.cachedKeys
+			k.mutex.Unlock()
+			return keys, nil
+		}
+
+		if r := k.refresh; r != nil {
+			k.mutex.Unlock()
+			select {
+			case <-r.done:
+				if r.cancelled && ctx.Err() == nil {
+					continue
+				}
+				return r.keys, r.err
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		r := &keyRefresh{done: make(chan struct{})}
+		k.refresh = r
+		k.mutex.Unlock()
+
+		keys, expiry, err := k.fetchKeys(ctx)
+
+		k.mutex.Lock()
+		if err == nil {
+			k.cachedKeys, k.expiryTime = keys, expiry
+		}
+		k.refresh = nil
+		k.mutex.Unlock()
+
+		r.keys, r.err = keys, err
+		r.cancelled = err != nil && ctx.Err() != nil
+		close(r.done)
+		return keys, err
+	}
+}
+
+func (k *HTTPKeySource) fetchKeys(ctx context.Context) ([]*PublicKey, time.Time, error) {
+	req, err := http.NewRequest(http.MethodGet, k.KeyURI, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	resp, err := k.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, time.Time{}, fmt.Errorf("invalid response (%d) while retrieving public keys: %s",
+			resp.StatusCode, string(contents))
+	}
+
+	keys, err := k.Parser(contents)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	maxAge, err := findMaxAge(resp)
+	if err != nil {
+		if k.DefaultMaxAge == 0 {
+			return nil, time.Time{}, err
+		}
+		maxAge = &k.DefaultMaxAge
+	}
+
+	return keys, k.Clock.Now().Add(*maxAge), nil
+}
+
+// ParseX509PublicKeys parses a JSON object that maps key IDs to PEM-encoded X.509 certificates,
+// as served by the Firebase Auth public key endpoints.
+func ParseX509PublicKeys(keys []byte) ([]*PublicKey, error) {
+	m := make(map[string]string)
+	err := json.Unmarshal(keys, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*PublicKey
+	for kid, key := range m {
+		pubKey, err := parseX509PublicKey(kid, []byte(key))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pubKey)
+	}
+	return result, nil
+}
+
+func parseX509PublicKey(kid string, key []byte) (*PublicKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.New("failed to decode the certificate as PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pk, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("certificate is not an RSA key")
+	}
+	return &PublicKey{kid, pk}, nil
+}
+
+// ParseJWKS parses the RSA keys of a JSON Web Key Set. Keys of other types are ignored.
+func ParseJWKS(contents []byte) ([]*PublicKey, error) {
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(contents, &jwks); err != nil {
+		return nil, err
+	}
+
+	var result []*PublicKey
+	for _, key := range jwks.Keys {
+		if key.Kty != "RSA" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode modulus of key %q: %v", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode exponent of key %q: %v", key.Kid, err)
+		}
+		result = append(result, &PublicKey{
+			Kid: key.Kid,
+			Key: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			},
+		})
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("no RSA keys found in the JWKS response")
+	}
+	return result, nil
+}
+
+func findMaxAge(resp *http.Response) (*time.Duration, error) {
+	cc := resp.Header.Get("cache-control")
+	for _, value := range strings.Split(cc, ",") {
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, "max-age=") {
+			sep := strings.Index(value, "=")
+			seconds, err := strconv.ParseInt(value[sep+1:], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			duration := time.Duration(seconds) * time.Second
+			return &duration, nil
+		}
+	}
+	return nil, errors.New("Could not find expiry time from HTTP headers")
+}
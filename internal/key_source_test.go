@@ -0,0 +1,472 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHTTPKeySource(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/public_certs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ks := NewHTTPKeySource("http://mock.url", http.DefaultClient, ParseX509PublicKeys)
+	if ks.HTTPClient == nil {
+		t.Errorf("HTTPClient = nil; want = non-nil")
+	}
+	hc, rc := newTestHTTPClient(data)
+	ks.HTTPClient = hc
+	if err := verifyHTTPKeySource(ks, rc); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHTTPKeySourceWithClient(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/public_certs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hc, rc := newTestHTTPClient(data)
+	ks := NewHTTPKeySource("http://mock.url", hc, ParseX509PublicKeys)
+	if ks.HTTPClient != hc {
+		t.Errorf("HTTPClient = %v; want = %v", ks.HTTPClient, hc)
+	}
+	if err := verifyHTTPKeySource(ks, rc); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHTTPKeySourceEmptyResponse(t *testing.T) {
+	hc, _ := newTestHTTPClient([]byte(""))
+	ks := NewHTTPKeySource("http://mock.url", hc, ParseX509PublicKeys)
+	if keys, err := ks.Keys(context.Background()); keys != nil || err == nil {
+		t.Errorf("Keys() = (%v, %v); want = (nil, error)", keys, err)
+	}
+}
+
+func TestHTTPKeySourceIncorrectResponse(t *testing.T) {
+	hc, _ := newTestHTTPClient([]byte("{\"foo\": \"bar\"}"))
+	ks := NewHTTPKeySource("http://mock.url", hc, ParseX509PublicKeys)
+	if keys, err := ks.Keys(context.Background()); keys != nil || err == nil {
+		t.Errorf("Keys() = (%v, %v); want = (nil, error)", keys, err)
+	}
+}
+
+func TestHTTPKeySourceHTTPError(t *testing.T) {
+	rc := &mockReadCloser{
+		data:       string(""),
+		closeCount: 0,
+	}
+	client := &http.Client{
+		Transport: &mockHTTPResponse{
+			Response: http.Response{
+				Status:     "503 Service Unavailable",
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       rc,
+			},
+			Err: nil,
+		},
+	}
+	ks := NewHTTPKeySource("http://mock.url", client, ParseX509PublicKeys)
+	if keys, err := ks.Keys(context.Background()); keys != nil || err == nil {
+		t.Errorf("Keys() = (%v, %v); want = (nil, error)", keys, err)
+	}
+}
+
+func TestHTTPKeySourceTransportError(t *testing.T) {
+	hc := &http.Client{
+		Transport: &mockHTTPResponse{
+			Err: errors.New("transport error"),
+		},
+	}
+	ks := NewHTTPKeySource("http://mock.url", hc, ParseX509PublicKeys)
+	if keys, err := ks.Keys(context.Background()); keys != nil || err == nil {
+		t.Errorf("Keys() = (%v, %v); want = (nil, error)", keys, err)
+	}
+}
+
+func TestFindMaxAge(t *testing.T) {
+	cases := []struct {
+		cc   string
+		want int64
+	}{
+		{"max-age=100", 100},
+		{"public, max-age=100", 100},
+		{"public,max-age=100", 100},
+	}
+	for _, tc := range cases {
+		resp := &http.Response{
+			Header: http.Header{"Cache-Control": {tc.cc}},
+		}
+		age, err := findMaxAge(resp)
+		if err != nil {
+			t.Errorf("findMaxAge(%q) = %v", tc.cc, err)
+		} else if *age != (time.Duration(tc.want) * time.Second) {
+			t.Errorf("findMaxAge(%q) = %v; want = %v", tc.cc, *age, tc.want)
+		}
+	}
+}
+
+func TestFindMaxAgeError(t *testing.T) {
+	cases := []string{
+		"",
+		"max-age 100",
+		"max-age: 100",
+		"max-age2=100",
+		"max-age=foo",
+	}
+	for _, tc := range cases {
+		resp := &http.Response{
+			Header: http.Header{"Cache-Control": []string{tc}},
+		}
+		if age, err := findMaxAge(resp); age != nil || err == nil {
+			t.Errorf("findMaxAge(%q) = (%v, %v); want = (nil, err)", tc, age, err)
+		}
+	}
+}
+
+func TestParseX509PublicKeys(t *testing.T) {
+	b, err := ioutil.ReadFile("../testdata/public_certs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := ParseX509PublicKeys(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 {
+		t.Errorf("ParseX509PublicKeys() = %d; want = %d", len(keys), 3)
+	}
+}
+
+func TestParseX509PublicKeysError(t *testing.T) {
+	cases := []string{
+		"",
+		"not-json",
+	}
+	for _, tc := range cases {
+		if keys, err := ParseX509PublicKeys([]byte(tc)); keys != nil || err == nil {
+			t.Errorf("ParseX509PublicKeys(%q) = (%v, %v); want = (nil, err)", tc, keys, err)
+		}
+	}
+}
+
+type mockHTTPResponse struct {
+	Response http.Response
+	Err      error
+}
+
+func (m *mockHTTPResponse) RoundTrip(*http.Request) (*http.Response, error) {
+	return &m.Response, m.Err
+}
+
+type mockReadCloser struct {
+	data       string
+	index      int64
+	closeCount int
+}
+
+func newTestHTTPClient(data []byte) (*http.Client, *mockReadCloser) {
+	rc := &mockReadCloser{
+		data:       string(data),
+		closeCount: 0,
+	}
+	client := &http.Client{
+		Transport: &mockHTTPResponse{
+			Response: http.Response{
+				Status:     "200 OK",
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Cache-Control": {"public, max-age=100"},
+				},
+				Body: rc,
+			},
+			Err: nil,
+		},
+	}
+	return client, rc
+}
+
+func (r *mockReadCloser) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if r.index >= int64(len(r.data)) {
+		return 0, io.EOF
+	}
+	n = copy(p, r.data[r.index:])
+	r.index += int64(n)
+	return
+}
+
+func (r *mockReadCloser) Close() error {
+	r.closeCount++
+	r.index = 0
+	return nil
+}
+
+func verifyHTTPKeySource(ks *HTTPKeySource, rc *mockReadCloser) error {
+	mc := &MockClock{Timestamp: time.Unix(0, 0)}
+	ks.Clock = mc
+
+	exp := time.Unix(100, 0)
+	for i := 0; i <= 100; i++ {
+		keys, err := ks.Keys(context.Background())
+		if err != nil {
+			return err
+		}
+		if len(keys) != 3 {
+			return fmt.Errorf("Keys: %d; want: 3", len(keys))
+		} else if rc.closeCount != 1 {
+			return fmt.Errorf("HTTP calls: %d; want: 1", rc.closeCount)
+		} else if ks.expiryTime != exp {
+			return fmt.Errorf("Expiry: %v; want: %v", ks.expiryTime, exp)
+		}
+		mc.Timestamp = mc.Timestamp.Add(time.Second)
+	}
+
+	mc.Timestamp = time.Unix(101, 0)
+	keys, err := ks.Keys(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(keys) != 3 {
+		return fmt.Errorf("Keys: %d; want: 3", len(keys))
+	} else if rc.closeCount != 2 {
+		return fmt.Errorf("HTTP calls: %d; want: 2", rc.closeCount)
+	}
+	return nil
+}
+
+func TestHTTPKeySourceDefaultMaxAge(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/public_certs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hc, rc := newTestHTTPClient(data)
+	hc.Transport.(*mockHTTPResponse).Response.Header = http.Header{}
+	ks := NewHTTPKeySource("http://mock.url", hc, ParseX509PublicKeys)
+	if keys, err := ks.Keys(context.Background()); keys != nil || err == nil {
+		t.Errorf("Keys() = (%v, %v); want = (nil, error)", keys, err)
+	}
+
+	ks.DefaultMaxAge = time.Hour
+	ks.Clock = &MockClock{Timestamp: time.Unix(0, 0)}
+	keys, err := ks.Keys(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 {
+		t.Errorf("Keys() = %d; want = 3", len(keys))
+	}
+	if ks.expiryTime != time.Unix(3600, 0) {
+		t.Errorf("Expiry = %v; want = %v", ks.expiryTime, time.Unix(3600, 0))
+	}
+	if rc.closeCount != 2 {
+		t.Errorf("HTTP calls = %d; want = 2", rc.closeCount)
+	}
+}
+
+func TestHTTPKeySourceConcurrentRefresh(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/public_certs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var calls int
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		if calls == 1 {
+			close(started)
+		}
+		mu.Unlock()
+		<-release
+		w.Header().Set("Cache-Control", "public, max-age=100")
+		w.Write(data)
+	}))
+	defer ts.Close()
+
+	ks := NewHTTPKeySource(ts.URL, http.DefaultClient, ParseX509PublicKeys)
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	fetch := func() {
+		defer wg.Done()
+		keys, err := ks.Keys(context.Background())
+		if err == nil && len(keys) != 3 {
+			err = fmt.Errorf("Keys() = %d; want = 3", len(keys))
+		}
+		errs <- err
+	}
+
+	// Start the remaining callers once the first refresh is in flight.
+	wg.Add(callers)
+	go fetch()
+	<-started
+	for i := 1; i < callers; i++ {
+		go fetch()
+	}
+
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("HTTP calls = %d; want = 1", calls)
+	}
+}
+
+func TestHTTPKeySourceContextCancelled(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	ks := NewHTTPKeySource(ts.URL, http.DefaultClient, ParseX509PublicKeys)
+	go ks.Keys(context.Background())
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if keys, err := ks.Keys(ctx); keys != nil || err != context.Canceled {
+		t.Errorf("Keys() = (%v, %v); want = (nil, %v)", keys, err, context.Canceled)
+	}
+}
+
+func TestHTTPKeySourceRefreshCancelled(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/public_certs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var calls int
+	started := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+		if first {
+			// Hold the first request until the caller that sent it gives up.
+			close(started)
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=100")
+		w.Write(data)
+	}))
+	defer ts.Close()
+
+	ks := NewHTTPKeySource(ts.URL, http.DefaultClient, ParseX509PublicKeys)
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := ks.Keys(ctx)
+		leaderErr <- err
+	}()
+	<-started
+
+	type result struct {
+		keys []*PublicKey
+		err  error
+	}
+	waiter := make(chan result, 1)
+	go func() {
+		keys, err := ks.Keys(context.Background())
+		waiter <- result{keys, err}
+	}()
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Keys(cancelled) = %v; want = %v", err, context.Canceled)
+	}
+	r := <-waiter
+	if r.err != nil || len(r.keys) != 3 {
+		t.Errorf("Keys() = (%d, %v); want = (3, nil)", len(r.keys), r.err)
+	}
+	if calls != 2 {
+		t.Errorf("HTTP calls = %d; want = 2", calls)
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks := fmt.Sprintf(`{"keys": [
+		{"kty": "EC", "kid": "ec-key"},
+		{"kty": "RSA", "kid": "rsa-key", "alg": "RS256", "use": "sig", "n": %q, "e": %q}
+	]}`,
+		base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()))
+	keys, err := ParseJWKS([]byte(jwks))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*PublicKey{{Kid: "rsa-key", Key: &key.PublicKey}}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("ParseJWKS() = %v; want = %v", keys, want)
+	}
+}
+
+func TestParseJWKSError(t *testing.T) {
+	cases := []string{
+		"",
+		"not-json",
+		`{"keys": []}`,
+		`{"keys": [{"kty": "EC", "kid": "ec-key"}]}`,
+		`{"keys": [{"kty": "RSA", "kid": "rsa-key", "n": "!!", "e": "AQAB"}]}`,
+		`{"keys": [{"kty": "RSA", "kid": "rsa-key", "n": "AQAB", "e": "!!"}]}`,
+	}
+	for _, tc := range cases {
+		if keys, err := ParseJWKS([]byte(tc)); keys != nil || err == nil {
+			t.Errorf("ParseJWKS(%q) = (%v, %v); want = (nil, err)", tc, keys, err)
+		}
+	}
+}
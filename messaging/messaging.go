@@ -866,6 +866,7 @@ func NewClient(ctx context.Context, c *internal.MessagingConfig) (*Client, error
 	if err != nil {
 		return nil, err
 	}
+	hc = c.WrapTransport.Wrap(hc)
 
 	batchEndpoint := messagingEndpoint
 
@@ -931,9 +932,26 @@ func (c *fcmClient) SendDryRun(ctx context.Context, message *Message) (string, e
 	return c.makeSendRequest(ctx, payload)
 }
 
+// SendRaw sends a Message to Firebase Cloud Messaging, and returns the raw JSON response body
+// received from the backend along with the message name.
+//
+// SendRaw is intended for diagnostic purposes, such as inspecting response fields that are not
+// yet modeled by the SDK. Send should be preferred in all other cases.
+func (c *fcmClient) SendRaw(ctx context.Context, message *Message) (string, json.RawMessage, error) {
+	payload := &fcmRequest{
+		Message: message,
+	}
+	return c.makeSendRequestRaw(ctx, payload)
+}
+
 func (c *fcmClient) makeSendRequest(ctx context.Context, req *fcmRequest) (string, error) {
+	name, _, err := c.makeSendRequestRaw(ctx, req)
+	return name, err
+}
+
+func (c *fcmClient) makeSendRequestRaw(ctx context.Context, req *fcmRequest) (string, json.RawMessage, error) {
 	if err := validateMessage(req.Message); err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	request := &internal.Request{
@@ -943,8 +961,11 @@ func (c *fcmClient) makeSendRequest(ctx context.Context, req *fcmRequest) (strin
 	}
 
 	var result fcmResponse
-	_, err := c.httpClient.DoAndUnmarshal(ctx, request, &result)
-	return result.Name, err
+	resp, err := c.httpClient.DoAndUnmarshal(ctx, request, &result)
+	if err != nil {
+		return "", nil, err
+	}
+	return result.Name, json.RawMessage(resp.Body), nil
 }
 
 // IsInternal checks if the given error was due to an internal server error.
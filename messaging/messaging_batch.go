@@ -27,11 +27,13 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"sync"
 
 	"firebase.google.com/go/v4/internal"
 )
 
 const maxMessages = 500
+const maxConcurrentSends = 50
 const multipartBoundary = "__END_OF_PART__"
 
 // MulticastMessage represents a message that can be sent to multiple devices via Firebase Cloud
@@ -80,7 +82,8 @@ type SendResponse struct {
 	Error     error
 }
 
-// BatchResponse represents the response from the `SendAll()` and `SendMulticast()` APIs.
+// BatchResponse represents the response from the `SendAll()`, `SendEach()`, `SendMulticast()` and
+// `SendEachForMulticast()` APIs.
 type BatchResponse struct {
 	SuccessCount int
 	FailureCount int
@@ -151,6 +154,64 @@ func (c *fcmClient) SendMulticastDryRun(ctx context.Context, message *MulticastM
 	return c.SendAllDryRun(ctx, messages)
 }
 
+// SendEach sends the messages in the given array via Firebase Cloud Messaging.
+//
+// The messages array may contain up to 500 messages. Unlike `SendAll()`, SendEach does not use
+// the batch endpoint. Instead, each message is sent as a separate call to the FCM v1 API, with at
+// most 50 calls in flight at any given time. The responses list obtained from the return value
+// corresponds to the order of the input messages. An error from SendEach indicates that the
+// messages could not be sent at all (e.g. due to invalid input) -- all other failures, including
+// context cancellation, are reported per message in the `BatchResponse` return value.
+func (c *fcmClient) SendEach(ctx context.Context, messages []*Message) (*BatchResponse, error) {
+	return c.sendEachInBatch(ctx, messages, false)
+}
+
+// SendEachDryRun sends the messages in the given array via Firebase Cloud Messaging in the
+// dry run (validation only) mode.
+//
+// This function does not actually deliver any messages to target devices. Instead, it performs all
+// the SDK-level and backend validations on the messages, and emulates the send operation.
+//
+// The messages array may contain up to 500 messages. SendEachDryRun sends each message as a
+// separate call to the FCM v1 API, with bounded concurrency. The responses list obtained from the
+// return value corresponds to the order of the input messages.
+func (c *fcmClient) SendEachDryRun(ctx context.Context, messages []*Message) (*BatchResponse, error) {
+	return c.sendEachInBatch(ctx, messages, true)
+}
+
+// SendEachForMulticast sends the given multicast message to all the FCM registration tokens
+// specified.
+//
+// The tokens array in MulticastMessage may contain up to 500 tokens. SendEachForMulticast uses the
+// `SendEach()` function to send the given message to all the target recipients. The responses
+// list obtained from the return value corresponds to the order of the input tokens.
+func (c *fcmClient) SendEachForMulticast(ctx context.Context, message *MulticastMessage) (*BatchResponse, error) {
+	messages, err := toMessages(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SendEach(ctx, messages)
+}
+
+// SendEachForMulticastDryRun sends the given multicast message to all the specified FCM
+// registration tokens in the dry run (validation only) mode.
+//
+// This function does not actually deliver any messages to target devices. Instead, it performs all
+// the SDK-level and backend validations on the messages, and emulates the send operation.
+//
+// The tokens array in MulticastMessage may contain up to 500 tokens. SendEachForMulticastDryRun
+// uses the `SendEachDryRun()` function to send the given message. The responses list obtained
+// from the return value corresponds to the order of the input tokens.
+func (c *fcmClient) SendEachForMulticastDryRun(ctx context.Context, message *MulticastMessage) (*BatchResponse, error) {
+	messages, err := toMessages(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SendEachDryRun(ctx, messages)
+}
+
 func toMessages(message *MulticastMessage) ([]*Message, error) {
 	if message == nil {
 		return nil, errors.New("message must not be nil")
@@ -187,6 +248,74 @@ func (c *fcmClient) sendBatch(
 	return newBatchResponse(resp)
 }
 
+func (c *fcmClient) sendEachInBatch(
+	ctx context.Context, messages []*Message, dryRun bool) (*BatchResponse, error) {
+
+	if len(messages) == 0 {
+		return nil, errors.New("messages must not be nil or empty")
+	}
+
+	if len(messages) > maxMessages {
+		return nil, fmt.Errorf("messages must not contain more than %d elements", maxMessages)
+	}
+
+	for idx, m := range messages {
+		if err := validateMessage(m); err != nil {
+			return nil, fmt.Errorf("invalid message at index %d: %v", idx, err)
+		}
+	}
+
+	responses := make([]*SendResponse, len(messages))
+	sem := make(chan struct{}, maxConcurrentSends)
+	var wg sync.WaitGroup
+	for idx, m := range messages {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		// Checked even when a slot was acquired, so that no new requests are started once the
+		// context is done.
+		if err := ctx.Err(); err != nil {
+			for i := idx; i < len(messages); i++ {
+				responses[i] = &SendResponse{Success: false, Error: err}
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func(idx int, m *Message) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			name, err := c.makeSendRequest(ctx, &fcmRequest{
+				Message:      m,
+				ValidateOnly: dryRun,
+			})
+			if err != nil {
+				responses[idx] = &SendResponse{Success: false, Error: err}
+			} else {
+				responses[idx] = &SendResponse{Success: true, MessageID: name}
+			}
+		}(idx, m)
+	}
+	wg.Wait()
+
+	var successCount int
+	for _, r := range responses {
+		if r.Success {
+			successCount++
+		}
+	}
+
+	return &BatchResponse{
+		SuccessCount: successCount,
+		FailureCount: len(responses) - successCount,
+		Responses:    responses,
+	}, nil
+}
+
 // part represents a HTTP request that can be sent embedded in a multipart batch request.
 //
 // See https://cloud.google.com/compute/docs/api/how-tos/batch for details on how GCP APIs support multipart batch
@@ -26,7 +26,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/textproto"
+	"sync"
 	"testing"
+	"time"
 
 	"google.golang.org/api/option"
 )
@@ -630,6 +632,330 @@ func TestSendMulticastPartialFailure(t *testing.T) {
 	}
 }
 
+func TestSendEachEmptyArray(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "messages must not be nil or empty"
+	br, err := client.SendEach(ctx, nil)
+	if err == nil || err.Error() != want {
+		t.Errorf("SendEach(nil) = (%v, %v); want = (nil, %q)", br, err, want)
+	}
+
+	br, err = client.SendEach(ctx, []*Message{})
+	if err == nil || err.Error() != want {
+		t.Errorf("SendEach(nil) = (%v, %v); want = (nil, %q)", br, err, want)
+	}
+}
+
+func TestSendEachTooManyMessages(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var messages []*Message
+	for i := 0; i < 501; i++ {
+		messages = append(messages, &Message{Topic: "test-topic"})
+	}
+
+	want := "messages must not contain more than 500 elements"
+	br, err := client.SendEach(ctx, messages)
+	if err == nil || err.Error() != want {
+		t.Errorf("SendEach() = (%v, %v); want = (nil, %q)", br, err, want)
+	}
+}
+
+func TestSendEachInvalidMessage(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "invalid message at index 1: message must not be nil"
+	br, err := client.SendEach(ctx, []*Message{{Topic: "topic1"}, nil})
+	if err == nil || err.Error() != want {
+		t.Errorf("SendEach() = (%v, %v); want = (nil, %q)", br, err, want)
+	}
+}
+
+func TestSendEach(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		ts, reqs := newSendEachServer(t)
+		ctx := context.Background()
+		client, err := NewClient(ctx, testMessagingConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.fcmEndpoint = ts.URL + "/v1"
+
+		var br *BatchResponse
+		if dryRun {
+			br, err = client.SendEachDryRun(ctx, testMessages)
+		} else {
+			br, err = client.SendEach(ctx, testMessages)
+		}
+		ts.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := checkSuccessfulSendEachResponse(br, reqs, "topic", dryRun); err != nil {
+			t.Errorf("SendEach(dryRun: %v) = %v", dryRun, err)
+		}
+	}
+}
+
+func TestSendEachPartialFailure(t *testing.T) {
+	var resp string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req fcmRequest
+		b, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(b, &req)
+		w.Header().Set("Content-Type", "application/json")
+		if req.Message.Topic == "topic2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(resp))
+			return
+		}
+		w.Write([]byte(`{"name":"` + testSuccessResponse[0].Name + `"}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+	client.fcmClient.httpClient.RetryConfig = nil
+
+	for idx, tc := range httpErrors {
+		resp = tc.resp
+		br, err := client.SendEach(ctx, testMessages)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := checkPartialErrorBatchResponse(br, tc); err != nil {
+			t.Errorf("[%d] SendEach() = %v", idx, err)
+		}
+	}
+}
+
+func TestSendEachBoundedConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight, count int
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		count++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		if inFlight == maxConcurrentSends {
+			close(release)
+		}
+		mu.Unlock()
+
+		select {
+		case <-release:
+		case <-time.After(5 * time.Second):
+		}
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"projects/test-project/messages/1"}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+
+	var messages []*Message
+	for i := 0; i < maxMessages; i++ {
+		messages = append(messages, &Message{Topic: "test-topic"})
+	}
+
+	br, err := client.SendEach(ctx, messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if br.SuccessCount != maxMessages || br.FailureCount != 0 {
+		t.Errorf("SendEach() = (%d, %d); want = (%d, 0)", br.SuccessCount, br.FailureCount, maxMessages)
+	}
+	if count != maxMessages {
+		t.Errorf("Requests = %d; want = %d", count, maxMessages)
+	}
+	if maxInFlight != maxConcurrentSends {
+		t.Errorf("MaxInFlight = %d; want = %d", maxInFlight, maxConcurrentSends)
+	}
+}
+
+func TestSendEachContextCancelled(t *testing.T) {
+	var mu sync.Mutex
+	var count int
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		count++
+		if count == maxConcurrentSends {
+			cancel()
+		}
+		mu.Unlock()
+		<-ctx.Done()
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(context.Background(), testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+
+	var messages []*Message
+	for i := 0; i < maxMessages; i++ {
+		messages = append(messages, &Message{Topic: "test-topic"})
+	}
+
+	br, err := client.SendEach(ctx, messages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if br.SuccessCount != 0 || br.FailureCount != maxMessages {
+		t.Errorf("SendEach() = (%d, %d); want = (0, %d)", br.SuccessCount, br.FailureCount, maxMessages)
+	}
+	if count != maxConcurrentSends {
+		t.Errorf("Requests = %d; want = %d", count, maxConcurrentSends)
+	}
+	for idx := maxConcurrentSends; idx < maxMessages; idx++ {
+		if err := br.Responses[idx].Error; err != context.Canceled {
+			t.Errorf("Responses[%d].Error = %v; want = %v", idx, err, context.Canceled)
+		}
+	}
+}
+
+func TestSendEachForMulticastNil(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "message must not be nil"
+	br, err := client.SendEachForMulticast(ctx, nil)
+	if err == nil || err.Error() != want {
+		t.Errorf("SendEachForMulticast(nil) = (%v, %v); want = (nil, %q)", br, err, want)
+	}
+
+	br, err = client.SendEachForMulticastDryRun(ctx, nil)
+	if err == nil || err.Error() != want {
+		t.Errorf("SendEachForMulticastDryRun(nil) = (%v, %v); want = (nil, %q)", br, err, want)
+	}
+}
+
+func TestSendEachForMulticast(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		ts, reqs := newSendEachServer(t)
+		ctx := context.Background()
+		client, err := NewClient(ctx, testMessagingConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.fcmEndpoint = ts.URL + "/v1"
+
+		var br *BatchResponse
+		if dryRun {
+			br, err = client.SendEachForMulticastDryRun(ctx, testMulticastMessage)
+		} else {
+			br, err = client.SendEachForMulticast(ctx, testMulticastMessage)
+		}
+		ts.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := checkSuccessfulSendEachResponse(br, reqs, "token", dryRun); err != nil {
+			t.Errorf("SendEachForMulticast(dryRun: %v) = %v", dryRun, err)
+		}
+	}
+}
+
+// newSendEachServer starts a server that responds to each send request with a message name
+// derived from the target of the message, and records the decoded requests by target.
+func newSendEachServer(t *testing.T) (*httptest.Server, map[string]*fcmRequest) {
+	var mu sync.Mutex
+	reqs := make(map[string]*fcmRequest)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != wantSendURL {
+			t.Errorf("Path = %q; want = %q", r.URL.Path, wantSendURL)
+		}
+
+		var req fcmRequest
+		b, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &req); err != nil {
+			t.Errorf("Unmarshal() = %v", err)
+		}
+
+		key := req.Message.Topic + req.Message.Token
+		mu.Lock()
+		reqs[key] = &req
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"projects/test-project/messages/` + key[len(key)-1:] + `"}`))
+	}))
+	return ts, reqs
+}
+
+func checkSuccessfulSendEachResponse(
+	br *BatchResponse, reqs map[string]*fcmRequest, prefix string, dryRun bool) error {
+
+	if br.SuccessCount != 2 {
+		return fmt.Errorf("SuccessCount = %d; want = 2", br.SuccessCount)
+	}
+	if br.FailureCount != 0 {
+		return fmt.Errorf("FailureCount = %d; want = 0", br.FailureCount)
+	}
+	if len(br.Responses) != 2 {
+		return fmt.Errorf("len(Responses) = %d; want = 2", len(br.Responses))
+	}
+
+	for idx, r := range br.Responses {
+		if err := checkSuccessfulSendResponse(r, testSuccessResponse[idx].Name); err != nil {
+			return fmt.Errorf("Responses[%d]: %v", idx, err)
+		}
+	}
+
+	if len(reqs) != 2 {
+		return fmt.Errorf("len(Requests) = %d; want = 2", len(reqs))
+	}
+	for i := 1; i <= 2; i++ {
+		key := fmt.Sprintf("%s%d", prefix, i)
+		req, ok := reqs[key]
+		if !ok {
+			return fmt.Errorf("Requests[%q] not found", key)
+		}
+		if req.ValidateOnly != dryRun {
+			return fmt.Errorf("Requests[%q].ValidateOnly = %v; want = %v", key, req.ValidateOnly, dryRun)
+		}
+	}
+
+	return nil
+}
+
 func checkSuccessfulBatchResponse(br *BatchResponse, req []byte, dryRun bool) error {
 	if br.SuccessCount != 2 {
 		return fmt.Errorf("SuccessCount = %d; want = 2", br.SuccessCount)
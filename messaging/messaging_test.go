@@ -1288,6 +1288,65 @@ func TestSendDryRun(t *testing.T) {
 	}
 }
 
+func TestSendRaw(t *testing.T) {
+	var tr *http.Request
+	var b []byte
+	resp := `{"name":"` + testMessageID + `","diagnostics":{"foo":"bar"}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tr = r
+		b, _ = ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(resp))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+
+	for _, tc := range validMessages {
+		t.Run(tc.name, func(t *testing.T) {
+			name, raw, err := client.SendRaw(ctx, tc.req)
+			if name != testMessageID || err != nil {
+				t.Errorf("SendRaw(%s) = (%q, %v); want = (%q, nil)", tc.name, name, err, testMessageID)
+			}
+			if string(raw) != resp {
+				t.Errorf("SendRaw(%s) raw = %q; want = %q", tc.name, string(raw), resp)
+			}
+			checkFCMRequest(t, b, tr, tc.want, false)
+		})
+	}
+}
+
+func TestSendRawError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"error": {"status": "INVALID_ARGUMENT", "message": "test error"}}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(ctx, testMessagingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.fcmEndpoint = ts.URL
+
+	name, raw, err := client.SendRaw(ctx, &Message{Topic: "topic"})
+	if name != "" || raw != nil || !errorutils.IsInvalidArgument(err) {
+		t.Errorf("SendRaw() = (%q, %q, %v); want = (\"\", nil, InvalidArgument)", name, string(raw), err)
+	}
+
+	name, raw, err = client.SendRaw(ctx, &Message{})
+	if name != "" || raw != nil || err == nil {
+		t.Errorf("SendRaw(InvalidMessage) = (%q, %q, %v); want = (\"\", nil, error)", name, string(raw), err)
+	}
+}
+
 func TestSendError(t *testing.T) {
 	var resp string
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
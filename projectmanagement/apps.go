@@ -0,0 +1,297 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectmanagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	androidApps = "androidApps"
+	iosApps     = "iosApps"
+)
+
+// AndroidApp represents an Android app registered in a Firebase project.
+type AndroidApp struct {
+	AppID       string `json:"appId"`
+	DisplayName string `json:"displayName"`
+	ProjectID   string `json:"projectId"`
+	PackageName string `json:"packageName"`
+}
+
+// IOSApp represents an iOS app registered in a Firebase project.
+type IOSApp struct {
+	AppID       string `json:"appId"`
+	DisplayName string `json:"displayName"`
+	ProjectID   string `json:"projectId"`
+	BundleID    string `json:"bundleId"`
+}
+
+// AndroidApp returns the Android app with the given app ID.
+func (c *Client) AndroidApp(ctx context.Context, appID string) (*AndroidApp, error) {
+	if appID == "" {
+		return nil, errors.New("app id must not be empty")
+	}
+
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL:    appResource(androidApps, appID),
+	}
+	var app AndroidApp
+	if err := c.makeRequest(ctx, req, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// IOSApp returns the iOS app with the given app ID.
+func (c *Client) IOSApp(ctx context.Context, appID string) (*IOSApp, error) {
+	if appID == "" {
+		return nil, errors.New("app id must not be empty")
+	}
+
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL:    appResource(iosApps, appID),
+	}
+	var app IOSApp
+	if err := c.makeRequest(ctx, req, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// CreateAndroidApp registers a new Android app with the given package name in the project.
+//
+// The display name is optional, and may be empty. CreateAndroidApp waits for the app to be
+// provisioned before returning it.
+func (c *Client) CreateAndroidApp(ctx context.Context, packageName, displayName string) (*AndroidApp, error) {
+	if packageName == "" {
+		return nil, errors.New("package name must not be empty")
+	}
+
+	body := map[string]string{"packageName": packageName}
+	if displayName != "" {
+		body["displayName"] = displayName
+	}
+	var app AndroidApp
+	if err := c.createApp(ctx, androidApps, body, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// CreateIOSApp registers a new iOS app with the given bundle ID in the project.
+//
+// The display name is optional, and may be empty. CreateIOSApp waits for the app to be
+// provisioned before returning it.
+func (c *Client) CreateIOSApp(ctx context.Context, bundleID, displayName string) (*IOSApp, error) {
+	if bundleID == "" {
+		return nil, errors.New("bundle id must not be empty")
+	}
+
+	body := map[string]string{"bundleId": bundleID}
+	if displayName != "" {
+		body["displayName"] = displayName
+	}
+	var app IOSApp
+	if err := c.createApp(ctx, iosApps, body, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// SetAndroidAppDisplayName updates the display name of the Android app with the given app ID.
+func (c *Client) SetAndroidAppDisplayName(ctx context.Context, appID, displayName string) error {
+	if appID == "" {
+		return errors.New("app id must not be empty")
+	}
+	return c.setDisplayName(ctx, appResource(androidApps, appID), displayName)
+}
+
+// SetIOSAppDisplayName updates the display name of the iOS app with the given app ID.
+func (c *Client) SetIOSAppDisplayName(ctx context.Context, appID, displayName string) error {
+	if appID == "" {
+		return errors.New("app id must not be empty")
+	}
+	return c.setDisplayName(ctx, appResource(iosApps, appID), displayName)
+}
+
+// AndroidAppConfig downloads the google-services.json config file of the Android app with the
+// given app ID.
+func (c *Client) AndroidAppConfig(ctx context.Context, appID string) (*AppConfig, error) {
+	if appID == "" {
+		return nil, errors.New("app id must not be empty")
+	}
+	return c.getConfig(ctx, appResource(androidApps, appID))
+}
+
+// IOSAppConfig downloads the GoogleService-Info.plist config file of the iOS app with the given
+// app ID.
+func (c *Client) IOSAppConfig(ctx context.Context, appID string) (*AppConfig, error) {
+	if appID == "" {
+		return nil, errors.New("app id must not be empty")
+	}
+	return c.getConfig(ctx, appResource(iosApps, appID))
+}
+
+// AndroidApps returns an iterator over the Android apps in the project.
+//
+// If nextPageToken is empty, the iterator will start at the beginning. Otherwise,
+// iterator starts after the token.
+func (c *Client) AndroidApps(ctx context.Context, nextPageToken string) *AndroidAppIterator {
+	it := &AndroidAppIterator{
+		ctx:    ctx,
+		client: c,
+	}
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(
+		it.fetch,
+		func() int { return len(it.apps) },
+		func() interface{} { b := it.apps; it.apps = nil; return b })
+	it.pageInfo.MaxSize = maxApps
+	it.pageInfo.Token = nextPageToken
+	return it
+}
+
+// IOSApps returns an iterator over the iOS apps in the project.
+//
+// If nextPageToken is empty, the iterator will start at the beginning. Otherwise,
+// iterator starts after the token.
+func (c *Client) IOSApps(ctx context.Context, nextPageToken string) *IOSAppIterator {
+	it := &IOSAppIterator{
+		ctx:    ctx,
+		client: c,
+	}
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(
+		it.fetch,
+		func() int { return len(it.apps) },
+		func() interface{} { b := it.apps; it.apps = nil; return b })
+	it.pageInfo.MaxSize = maxApps
+	it.pageInfo.Token = nextPageToken
+	return it
+}
+
+// AndroidAppIterator is an iterator over Android apps.
+type AndroidAppIterator struct {
+	client   *Client
+	ctx      context.Context
+	nextFunc func() error
+	pageInfo *iterator.PageInfo
+	apps     []*AndroidApp
+}
+
+// PageInfo supports pagination.
+func (it *AndroidAppIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next AndroidApp. The error value of [iterator.Done] is
+// returned if there are no more results. Once Next returns [iterator.Done], all
+// subsequent calls will return [iterator.Done].
+func (it *AndroidAppIterator) Next() (*AndroidApp, error) {
+	if err := it.nextFunc(); err != nil {
+		return nil, err
+	}
+
+	app := it.apps[0]
+	it.apps = it.apps[1:]
+	return app, nil
+}
+
+func (it *AndroidAppIterator) fetch(pageSize int, pageToken string) (string, error) {
+	var result struct {
+		Apps          []*AndroidApp `json:"apps"`
+		NextPageToken string        `json:"nextPageToken"`
+	}
+	if err := it.client.listApps(it.ctx, androidApps, pageSize, pageToken, &result); err != nil {
+		return "", err
+	}
+
+	it.apps = append(it.apps, result.Apps...)
+	it.pageInfo.Token = result.NextPageToken
+	return result.NextPageToken, nil
+}
+
+// IOSAppIterator is an iterator over iOS apps.
+type IOSAppIterator struct {
+	client   *Client
+	ctx      context.Context
+	nextFunc func() error
+	pageInfo *iterator.PageInfo
+	apps     []*IOSApp
+}
+
+// PageInfo supports pagination.
+func (it *IOSAppIterator) PageInfo() *iterator.PageInfo {
+	return it.pageInfo
+}
+
+// Next returns the next IOSApp. The error value of [iterator.Done] is
+// returned if there are no more results. Once Next returns [iterator.Done], all
+// subsequent calls will return [iterator.Done].
+func (it *IOSAppIterator) Next() (*IOSApp, error) {
+	if err := it.nextFunc(); err != nil {
+		return nil, err
+	}
+
+	app := it.apps[0]
+	it.apps = it.apps[1:]
+	return app, nil
+}
+
+func (it *IOSAppIterator) fetch(pageSize int, pageToken string) (string, error) {
+	var result struct {
+		Apps          []*IOSApp `json:"apps"`
+		NextPageToken string    `json:"nextPageToken"`
+	}
+	if err := it.client.listApps(it.ctx, iosApps, pageSize, pageToken, &result); err != nil {
+		return "", err
+	}
+
+	it.apps = append(it.apps, result.Apps...)
+	it.pageInfo.Token = result.NextPageToken
+	return result.NextPageToken, nil
+}
+
+func (c *Client) listApps(ctx context.Context, collection string, pageSize int, pageToken string, v interface{}) error {
+	params := map[string]string{
+		"pageSize": strconv.Itoa(pageSize),
+	}
+	if pageToken != "" {
+		params["pageToken"] = pageToken
+	}
+
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("projects/%s/%s", c.project, collection),
+		Opts: []internal.HTTPOption{
+			internal.WithQueryParams(params),
+		},
+	}
+	return c.makeRequest(ctx, req, v)
+}
+
+// appResource returns the resource name of an app. The Firebase Management API accepts "-" in
+// place of the project ID, since app IDs are globally unique.
+func appResource(collection, appID string) string {
+	return fmt.Sprintf("projects/-/%s/%s", collection, appID)
+}
@@ -0,0 +1,313 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectmanagement
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"firebase.google.com/go/v4/errorutils"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	testAndroidAppResponse = `{
+		"name": "projects/test-project/androidApps/1:123:android:abc",
+		"appId": "1:123:android:abc",
+		"displayName": "Test Android App",
+		"projectId": "test-project",
+		"packageName": "com.example.android"
+	}`
+
+	testIOSAppResponse = `{
+		"name": "projects/test-project/iosApps/1:123:ios:abc",
+		"appId": "1:123:ios:abc",
+		"displayName": "Test iOS App",
+		"projectId": "test-project",
+		"bundleId": "com.example.ios"
+	}`
+)
+
+var testAndroidApp = &AndroidApp{
+	AppID:       "1:123:android:abc",
+	DisplayName: "Test Android App",
+	ProjectID:   "test-project",
+	PackageName: "com.example.android",
+}
+
+var testIOSApp = &IOSApp{
+	AppID:       "1:123:ios:abc",
+	DisplayName: "Test iOS App",
+	ProjectID:   "test-project",
+	BundleID:    "com.example.ios",
+}
+
+func TestAndroidApp(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{Resps: []mockResponse{{Body: testAndroidAppResponse}}}
+	defer s.Start(client).Close()
+
+	app, err := client.AndroidApp(context.Background(), "1:123:android:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(app, testAndroidApp) {
+		t.Errorf("AndroidApp() = %#v; want = %#v", app, testAndroidApp)
+	}
+	checkRequests(t, s.Reqs, []*testRequest{
+		{Method: http.MethodGet, Path: "/projects/-/androidApps/1:123:android:abc"},
+	})
+}
+
+func TestIOSApp(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{Resps: []mockResponse{{Body: testIOSAppResponse}}}
+	defer s.Start(client).Close()
+
+	app, err := client.IOSApp(context.Background(), "1:123:ios:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(app, testIOSApp) {
+		t.Errorf("IOSApp() = %#v; want = %#v", app, testIOSApp)
+	}
+	checkRequests(t, s.Reqs, []*testRequest{
+		{Method: http.MethodGet, Path: "/projects/-/iosApps/1:123:ios:abc"},
+	})
+}
+
+func TestAndroidAppNotFound(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resps: []mockResponse{{
+			Status: http.StatusNotFound,
+			Body:   `{"error": {"status": "NOT_FOUND", "message": "app not found"}}`,
+		}},
+	}
+	defer s.Start(client).Close()
+
+	app, err := client.AndroidApp(context.Background(), "1:123:android:abc")
+	if app != nil || err == nil || err.Error() != "app not found" {
+		t.Errorf("AndroidApp() = (%v, %v); want = (nil, %q)", app, err, "app not found")
+	}
+	if !errorutils.IsNotFound(err) {
+		t.Errorf("AndroidApp() = %v; want = NotFound", err)
+	}
+}
+
+func TestCreateAndroidApp(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resps: []mockResponse{
+			{Body: `{"name": "operations/create-android"}`},
+			{Body: `{"name": "operations/create-android", "done": true, "response": ` +
+				testAndroidAppResponse + `}`},
+		},
+	}
+	defer s.Start(client).Close()
+
+	app, err := client.CreateAndroidApp(context.Background(), "com.example.android", "Test Android App")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(app, testAndroidApp) {
+		t.Errorf("CreateAndroidApp() = %#v; want = %#v", app, testAndroidApp)
+	}
+	checkRequests(t, s.Reqs, []*testRequest{
+		{
+			Method: http.MethodPost,
+			Path:   "/projects/test-project/androidApps",
+			Body:   []byte(`{"packageName": "com.example.android", "displayName": "Test Android App"}`),
+		},
+		{Method: http.MethodGet, Path: "/operations/create-android"},
+	})
+}
+
+func TestCreateIOSApp(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resps: []mockResponse{
+			{Body: `{"name": "operations/create-ios"}`},
+			{Body: `{"name": "operations/create-ios", "done": true, "response": ` +
+				testIOSAppResponse + `}`},
+		},
+	}
+	defer s.Start(client).Close()
+
+	app, err := client.CreateIOSApp(context.Background(), "com.example.ios", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(app, testIOSApp) {
+		t.Errorf("CreateIOSApp() = %#v; want = %#v", app, testIOSApp)
+	}
+	checkRequests(t, s.Reqs, []*testRequest{
+		{
+			Method: http.MethodPost,
+			Path:   "/projects/test-project/iosApps",
+			Body:   []byte(`{"bundleId": "com.example.ios"}`),
+		},
+		{Method: http.MethodGet, Path: "/operations/create-ios"},
+	})
+}
+
+func TestSetDisplayName(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{Resps: []mockResponse{{Body: "{}"}}}
+	defer s.Start(client).Close()
+
+	ctx := context.Background()
+	if err := client.SetAndroidAppDisplayName(ctx, "1:123:android:abc", "New Name"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetIOSAppDisplayName(ctx, "1:123:ios:abc", "New Name"); err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`{"displayName": "New Name"}`)
+	checkRequests(t, s.Reqs, []*testRequest{
+		{
+			Method: http.MethodPatch,
+			Path:   "/projects/-/androidApps/1:123:android:abc",
+			Query:  "updateMask=displayName",
+			Body:   body,
+		},
+		{
+			Method: http.MethodPatch,
+			Path:   "/projects/-/iosApps/1:123:ios:abc",
+			Query:  "updateMask=displayName",
+			Body:   body,
+		},
+	})
+}
+
+func TestAppConfig(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resps: []mockResponse{{
+			Body: `{"configFilename": "google-services.json", "configFileContents": "eyJ0ZXN0IjogdHJ1ZX0="}`,
+		}},
+	}
+	defer s.Start(client).Close()
+
+	ctx := context.Background()
+	want := &AppConfig{Filename: "google-services.json", Contents: []byte(`{"test": true}`)}
+	config, err := client.AndroidAppConfig(ctx, "1:123:android:abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("AndroidAppConfig() = %#v; want = %#v", config, want)
+	}
+
+	if _, err := client.IOSAppConfig(ctx, "1:123:ios:abc"); err != nil {
+		t.Fatal(err)
+	}
+	checkRequests(t, s.Reqs, []*testRequest{
+		{Method: http.MethodGet, Path: "/projects/-/androidApps/1:123:android:abc/config"},
+		{Method: http.MethodGet, Path: "/projects/-/iosApps/1:123:ios:abc/config"},
+	})
+}
+
+func TestAndroidApps(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resps: []mockResponse{
+			{Body: `{"apps": [` + testAndroidAppResponse + `], "nextPageToken": "token"}`},
+			{Body: `{"apps": [` + testAndroidAppResponse + `]}`},
+		},
+	}
+	defer s.Start(client).Close()
+
+	it := client.AndroidApps(context.Background(), "")
+	var apps []*AndroidApp
+	for {
+		app, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		apps = append(apps, app)
+	}
+
+	if len(apps) != 2 {
+		t.Fatalf("AndroidApps() = %d; want = 2", len(apps))
+	}
+	for _, app := range apps {
+		if !reflect.DeepEqual(app, testAndroidApp) {
+			t.Errorf("AndroidApps() = %#v; want = %#v", app, testAndroidApp)
+		}
+	}
+	checkRequests(t, s.Reqs, []*testRequest{
+		{Method: http.MethodGet, Path: "/projects/test-project/androidApps", Query: "pageSize=100"},
+		{Method: http.MethodGet, Path: "/projects/test-project/androidApps", Query: "pageSize=100&pageToken=token"},
+	})
+}
+
+func TestIOSApps(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{Resps: []mockResponse{{Body: `{"apps": [` + testIOSAppResponse + `]}`}}}
+	defer s.Start(client).Close()
+
+	it := client.IOSApps(context.Background(), "")
+	app, err := it.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(app, testIOSApp) {
+		t.Errorf("IOSApps() = %#v; want = %#v", app, testIOSApp)
+	}
+	if _, err := it.Next(); err != iterator.Done {
+		t.Errorf("Next() = %v; want = %v", err, iterator.Done)
+	}
+}
+
+func TestAppsInvalidArgs(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	if app, err := client.AndroidApp(ctx, ""); app != nil || err == nil {
+		t.Errorf("AndroidApp('') = (%v, %v); want = (nil, error)", app, err)
+	}
+	if app, err := client.IOSApp(ctx, ""); app != nil || err == nil {
+		t.Errorf("IOSApp('') = (%v, %v); want = (nil, error)", app, err)
+	}
+	if app, err := client.CreateAndroidApp(ctx, "", "name"); app != nil || err == nil {
+		t.Errorf("CreateAndroidApp('') = (%v, %v); want = (nil, error)", app, err)
+	}
+	if app, err := client.CreateIOSApp(ctx, "", "name"); app != nil || err == nil {
+		t.Errorf("CreateIOSApp('') = (%v, %v); want = (nil, error)", app, err)
+	}
+	if err := client.SetAndroidAppDisplayName(ctx, "", "name"); err == nil {
+		t.Errorf("SetAndroidAppDisplayName('') = nil; want = error")
+	}
+	if err := client.SetIOSAppDisplayName(ctx, "", "name"); err == nil {
+		t.Errorf("SetIOSAppDisplayName('') = nil; want = error")
+	}
+	if config, err := client.AndroidAppConfig(ctx, ""); config != nil || err == nil {
+		t.Errorf("AndroidAppConfig('') = (%v, %v); want = (nil, error)", config, err)
+	}
+	if config, err := client.IOSAppConfig(ctx, ""); config != nil || err == nil {
+		t.Errorf("IOSAppConfig('') = (%v, %v); want = (nil, error)", config, err)
+	}
+}
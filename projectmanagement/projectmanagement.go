@@ -0,0 +1,201 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package projectmanagement contains functions for managing the Android and iOS apps of a
+// Firebase project.
+package projectmanagement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const (
+	defaultURL           = "https://firebase.googleapis.com"
+	firebaseClientHeader = "X-Firebase-Client"
+
+	maxApps = 100
+
+	initialPollInterval = 500 * time.Millisecond
+	maxPollInterval     = 8 * time.Second
+	maxPollAttempts     = 10
+)
+
+// AppConfig is the configuration file of an app, which is used to initialize the Firebase
+// client SDKs on a device.
+type AppConfig struct {
+	// Filename is the name of the config file (google-services.json or
+	// GoogleService-Info.plist).
+	Filename string `json:"configFilename"`
+
+	// Contents is the content of the config file.
+	Contents []byte `json:"configFileContents"`
+}
+
+// Client is the interface for the Firebase Project Management service.
+type Client struct {
+	// To enable testing against arbitrary endpoints.
+	endpoint     string
+	pollInterval time.Duration
+	client       *internal.HTTPClient
+	project      string
+}
+
+// NewClient creates a new instance of the Firebase Project Management Client.
+//
+// This function can only be invoked from within the SDK. Client applications should access the
+// Project Management service through firebase.App.
+func NewClient(ctx context.Context, c *internal.ProjectManagementConfig) (*Client, error) {
+	if c.ProjectID == "" {
+		return nil, errors.New("project id is required to access project management client")
+	}
+
+	hc, _, err := internal.NewHTTPClient(ctx, c.Opts...)
+	if err != nil {
+		return nil, err
+	}
+	hc.Client = c.WrapTransport.Wrap(hc.Client)
+
+	baseURL := defaultURL
+	if c.Endpoint != "" {
+		baseURL = strings.TrimSuffix(c.Endpoint, "/")
+	}
+
+	hc.CreateErrFn = createError
+	hc.Opts = []internal.HTTPOption{
+		internal.WithHeader(firebaseClientHeader, fmt.Sprintf("fire-admin-go/%s", c.Version)),
+	}
+	return &Client{
+		endpoint:     baseURL + "/v1beta1",
+		pollInterval: initialPollInterval,
+		client:       hc,
+		project:      c.ProjectID,
+	}, nil
+}
+
+func createError(resp *internal.Response) error {
+	return internal.NewFirebaseErrorOnePlatform(resp)
+}
+
+func (c *Client) makeRequest(ctx context.Context, req *internal.Request, v interface{}) error {
+	req.URL = fmt.Sprintf("%s/%s", c.endpoint, req.URL)
+	_, err := c.client.DoAndUnmarshal(ctx, req, v)
+	return err
+}
+
+// getConfig downloads the config file of the app with the given resource name.
+func (c *Client) getConfig(ctx context.Context, resource string) (*AppConfig, error) {
+	req := &internal.Request{
+		Method: http.MethodGet,
+		URL:    fmt.Sprintf("%s/config", resource),
+	}
+	var config AppConfig
+	if err := c.makeRequest(ctx, req, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// setDisplayName updates the display name of the app with the given resource name.
+func (c *Client) setDisplayName(ctx context.Context, resource, displayName string) error {
+	req := &internal.Request{
+		Method: http.MethodPatch,
+		URL:    resource,
+		Body:   internal.NewJSONEntity(map[string]string{"displayName": displayName}),
+		Opts: []internal.HTTPOption{
+			internal.WithQueryParam("updateMask", "displayName"),
+		},
+	}
+	return c.makeRequest(ctx, req, nil)
+}
+
+// createApp starts the creation of an app, and waits for the resulting long-running operation
+// to complete. The created app is unmarshalled into v.
+func (c *Client) createApp(ctx context.Context, collection string, body interface{}, v interface{}) error {
+	req := &internal.Request{
+		Method: http.MethodPost,
+		URL:    fmt.Sprintf("projects/%s/%s", c.project, collection),
+		Body:   internal.NewJSONEntity(body),
+	}
+	var op operation
+	if err := c.makeRequest(ctx, req, &op); err != nil {
+		return err
+	}
+	if op.Name == "" {
+		return errors.New("failed to start app creation: operation name not found in response")
+	}
+
+	return c.waitForOperation(ctx, &op, v)
+}
+
+// operation is a long-running operation as returned by the Firebase Management API.
+type operation struct {
+	Name     string          `json:"name"`
+	Done     bool            `json:"done"`
+	Response json.RawMessage `json:"response"`
+	Error    *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+func (c *Client) waitForOperation(ctx context.Context, op *operation, v interface{}) error {
+	delay := c.pollInterval
+	for i := 0; !op.Done; i++ {
+		if i == maxPollAttempts {
+			return fmt.Errorf("timed out waiting for operation %q to complete", op.Name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxPollInterval {
+			delay = maxPollInterval
+		}
+
+		req := &internal.Request{
+			Method: http.MethodGet,
+			URL:    op.Name,
+		}
+		if err := c.makeRequest(ctx, req, op); err != nil {
+			return err
+		}
+	}
+
+	if op.Error != nil {
+		code := internal.ErrorCode(op.Error.Status)
+		if code == "" {
+			code = internal.Unknown
+		}
+		return &internal.FirebaseError{
+			ErrorCode: code,
+			String:    op.Error.Message,
+			Ext:       make(map[string]interface{}),
+		}
+	}
+	if err := json.Unmarshal(op.Response, v); err != nil {
+		return fmt.Errorf("error while parsing operation response: %v", err)
+	}
+	return nil
+}
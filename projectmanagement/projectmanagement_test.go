@@ -0,0 +1,213 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projectmanagement
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/errorutils"
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/option"
+)
+
+var testProjectManagementConfig = &internal.ProjectManagementConfig{
+	ProjectID: "test-project",
+	Opts: []option.ClientOption{
+		option.WithTokenSource(&internal.MockTokenSource{AccessToken: "test-token"}),
+	},
+	Version: "test-version",
+}
+
+type mockResponse struct {
+	Status int
+	Body   string
+}
+
+type testRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// mockServer serves the scripted responses in order, one per incoming request. Once the script
+// is exhausted, the last response is repeated.
+type mockServer struct {
+	Resps []mockResponse
+	Reqs  []*testRequest
+	srv   *httptest.Server
+}
+
+func (s *mockServer) Start(c *Client) *httptest.Server {
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		s.Reqs = append(s.Reqs, &testRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.RawQuery,
+			Body:   b,
+		})
+
+		idx := len(s.Reqs) - 1
+		if idx >= len(s.Resps) {
+			idx = len(s.Resps) - 1
+		}
+		resp := s.Resps[idx]
+		w.Header().Set("Content-Type", "application/json")
+		if resp.Status != 0 {
+			w.WriteHeader(resp.Status)
+		}
+		w.Write([]byte(resp.Body))
+	}))
+	c.endpoint = s.srv.URL
+	c.pollInterval = time.Millisecond
+	return s.srv
+}
+
+func newTestClient(t *testing.T) *Client {
+	client, err := NewClient(context.Background(), testProjectManagementConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func checkRequests(t *testing.T, got []*testRequest, want []*testRequest) {
+	if len(got) != len(want) {
+		t.Fatalf("Requests = %d; want = %d", len(got), len(want))
+	}
+	for i, r := range want {
+		if got[i].Method != r.Method {
+			t.Errorf("Requests[%d].Method = %q; want = %q", i, got[i].Method, r.Method)
+		}
+		if got[i].Path != r.Path {
+			t.Errorf("Requests[%d].Path = %q; want = %q", i, got[i].Path, r.Path)
+		}
+		if got[i].Query != r.Query {
+			t.Errorf("Requests[%d].Query = %q; want = %q", i, got[i].Query, r.Query)
+		}
+		if r.Body != nil {
+			var gotBody, wantBody interface{}
+			json.Unmarshal(got[i].Body, &gotBody)
+			json.Unmarshal(r.Body, &wantBody)
+			if !reflect.DeepEqual(gotBody, wantBody) {
+				t.Errorf("Requests[%d].Body = %s; want = %s", i, got[i].Body, r.Body)
+			}
+		}
+	}
+}
+
+func TestNoProjectID(t *testing.T) {
+	client, err := NewClient(context.Background(), &internal.ProjectManagementConfig{})
+	if client != nil || err == nil {
+		t.Errorf("NewClient() = (%v, %v); want = (nil, error)", client, err)
+	}
+}
+
+func TestNewClientWithEndpoint(t *testing.T) {
+	conf := &internal.ProjectManagementConfig{
+		ProjectID: "test-project",
+		Opts:      testProjectManagementConfig.Opts,
+		Endpoint:  "https://pm.example.com",
+	}
+	client, err := NewClient(context.Background(), conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.endpoint != "https://pm.example.com/v1beta1" {
+		t.Errorf("endpoint = %q; want = %q", client.endpoint, "https://pm.example.com/v1beta1")
+	}
+}
+
+func TestWaitForOperation(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resps: []mockResponse{
+			{Body: `{"name": "operations/test-op", "done": false}`},
+			{Body: `{"name": "operations/test-op", "done": true, "response": {"appId": "test-app"}}`},
+		},
+	}
+	defer s.Start(client).Close()
+
+	var app AndroidApp
+	op := &operation{Name: "operations/test-op"}
+	if err := client.waitForOperation(context.Background(), op, &app); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.AppID != "test-app" {
+		t.Errorf("AppID = %q; want = %q", app.AppID, "test-app")
+	}
+	want := &testRequest{Method: http.MethodGet, Path: "/operations/test-op"}
+	checkRequests(t, s.Reqs, []*testRequest{want, want})
+}
+
+func TestWaitForOperationError(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resps: []mockResponse{
+			{Body: `{"name": "operations/test-op", "done": true, "error": {
+				"code": 409, "message": "app already exists", "status": "ALREADY_EXISTS"}}`},
+		},
+	}
+	defer s.Start(client).Close()
+
+	op := &operation{Name: "operations/test-op"}
+	err := client.waitForOperation(context.Background(), op, &AndroidApp{})
+	if err == nil || err.Error() != "app already exists" {
+		t.Errorf("waitForOperation() = %v; want = %q", err, "app already exists")
+	}
+	if !errorutils.IsAlreadyExists(err) {
+		t.Errorf("waitForOperation() = %v; want = AlreadyExists", err)
+	}
+}
+
+func TestWaitForOperationTimeout(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resps: []mockResponse{
+			{Body: `{"name": "operations/test-op", "done": false}`},
+		},
+	}
+	defer s.Start(client).Close()
+
+	op := &operation{Name: "operations/test-op"}
+	err := client.waitForOperation(context.Background(), op, &AndroidApp{})
+	want := `timed out waiting for operation "operations/test-op" to complete`
+	if err == nil || err.Error() != want {
+		t.Errorf("waitForOperation() = %v; want = %q", err, want)
+	}
+	if len(s.Reqs) != maxPollAttempts {
+		t.Errorf("Requests = %d; want = %d", len(s.Reqs), maxPollAttempts)
+	}
+}
+
+func TestWaitForOperationContextCancelled(t *testing.T) {
+	client := newTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	op := &operation{Name: "operations/test-op"}
+	if err := client.waitForOperation(ctx, op, &AndroidApp{}); err != context.Canceled {
+		t.Errorf("waitForOperation() = %v; want = %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remoteconfig contains functions for managing the Remote Config template of a Firebase
+// project.
+package remoteconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"firebase.google.com/go/v4/internal"
+)
+
+const (
+	defaultRemoteConfigURL = "https://firebaseremoteconfig.googleapis.com"
+
+	firebaseClientHeader = "X-Firebase-Client"
+	etagHeader           = "ETag"
+	ifMatchHeader        = "If-Match"
+
+	// ForceETag can be set as the ETag of a Template to publish it unconditionally, overwriting
+	// any changes made to the template since it was last retrieved.
+	ForceETag = "*"
+)
+
+// ParameterValueType is the data type of the values of a Remote Config parameter.
+type ParameterValueType string
+
+const (
+	// String indicates that the parameter values are strings.
+	String ParameterValueType = "STRING"
+
+	// Boolean indicates that the parameter values are booleans.
+	Boolean ParameterValueType = "BOOLEAN"
+
+	// Number indicates that the parameter values are numbers.
+	Number ParameterValueType = "NUMBER"
+
+	// JSON indicates that the parameter values are JSON strings.
+	JSON ParameterValueType = "JSON"
+)
+
+// Template represents a Remote Config template.
+//
+// ETag identifies the version of the template it was retrieved as. It is sent back to the
+// server when the template is published, so that the publish request fails if the template has
+// been modified by somebody else in the meantime.
+type Template struct {
+	Conditions      []*Condition               `json:"conditions,omitempty"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty"`
+	ParameterGroups map[string]*ParameterGroup `json:"parameterGroups,omitempty"`
+	Version         *Version                   `json:"version,omitempty"`
+	ETag            string                     `json:"-"`
+}
+
+// Condition targets a specific group of users. Conditions are evaluated in the order they
+// appear in the template, and the first one that matches determines the value of a parameter.
+type Condition struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	TagColor   string `json:"tagColor,omitempty"`
+}
+
+// Parameter is a key in a Remote Config template, and the values it may take.
+//
+// ConditionalValues maps condition names to the values the parameter takes when the
+// corresponding condition matches.
+type Parameter struct {
+	DefaultValue      *ParameterValue            `json:"defaultValue,omitempty"`
+	ConditionalValues map[string]*ParameterValue `json:"conditionalValues,omitempty"`
+	Description       string                     `json:"description,omitempty"`
+	ValueType         ParameterValueType         `json:"valueType,omitempty"`
+}
+
+// ParameterValue is a value of a Remote Config parameter.
+//
+// A ParameterValue holds exactly one kind of value. When UseInAppDefault is true, clients fall
+// back to the default value defined in the app. When PersonalizationValue or RolloutValue is set,
+// the value is determined by the corresponding Personalization or Rollout. Otherwise the value is
+// Value.
+//
+// Values of a kind that is not modeled by ParameterValue are preserved when a template is
+// retrieved, and sent back unchanged when it is published, as long as none of the fields of the
+// ParameterValue are set.
+type ParameterValue struct {
+	Value                string
+	UseInAppDefault      bool
+	PersonalizationValue *PersonalizationValue
+	RolloutValue         *RolloutValue
+
+	// unknown holds the JSON representation of a value of a kind not modeled above.
+	unknown json.RawMessage
+}
+
+// PersonalizationValue is a parameter value determined by a Remote Config Personalization.
+type PersonalizationValue struct {
+	PersonalizationID string `json:"personalizationId"`
+}
+
+// RolloutValue is a parameter value provided by a Remote Config Rollout to the given
+// percentage of the targeted users.
+type RolloutValue struct {
+	RolloutID string  `json:"rolloutId"`
+	Value     string  `json:"value"`
+	Percent   float64 `json:"percent"`
+}
+
+// MarshalJSON marshals a ParameterValue into JSON.
+func (v *ParameterValue) MarshalJSON() ([]byte, error) {
+	switch {
+	case v.UseInAppDefault:
+		return json.Marshal(map[string]bool{"useInAppDefault": true})
+	case v.PersonalizationValue != nil:
+		return json.Marshal(map[string]*PersonalizationValue{"personalizationValue": v.PersonalizationValue})
+	case v.RolloutValue != nil:
+		return json.Marshal(map[string]*RolloutValue{"rolloutValue": v.RolloutValue})
+	case v.unknown != nil && v.Value == "":
+		return v.unknown, nil
+	default:
+		return json.Marshal(map[string]string{"value": v.Value})
+	}
+}
+
+// UnmarshalJSON unmarshals a JSON string into a ParameterValue.
+func (v *ParameterValue) UnmarshalJSON(b []byte) error {
+	var temp struct {
+		Value                *string               `json:"value"`
+		UseInAppDefault      bool                  `json:"useInAppDefault"`
+		PersonalizationValue *PersonalizationValue `json:"personalizationValue"`
+		RolloutValue         *RolloutValue         `json:"rolloutValue"`
+	}
+	if err := json.Unmarshal(b, &temp); err != nil {
+		return err
+	}
+
+	*v = ParameterValue{
+		UseInAppDefault:      temp.UseInAppDefault,
+		PersonalizationValue: temp.PersonalizationValue,
+		RolloutValue:         temp.RolloutValue,
+	}
+	if temp.Value != nil {
+		v.Value = *temp.Value
+	}
+	if temp.Value == nil && !temp.UseInAppDefault && temp.PersonalizationValue == nil &&
+		temp.RolloutValue == nil {
+		v.unknown = append(json.RawMessage(nil), b...)
+	}
+	return nil
+}
+
+// ParameterGroup is a named group of parameters, used for organizing parameters in the
+// Firebase console.
+type ParameterGroup struct {
+	Description string                `json:"description,omitempty"`
+	Parameters  map[string]*Parameter `json:"parameters,omitempty"`
+}
+
+// Version contains the metadata of a published Remote Config template.
+//
+// Only the Description field is sent to the server when publishing a template. The other
+// fields are set by the server.
+type Version struct {
+	VersionNumber  string    `json:"versionNumber,omitempty"`
+	UpdateTime     time.Time `json:"updateTime"`
+	UpdateUser     *User     `json:"updateUser,omitempty"`
+	Description    string    `json:"description,omitempty"`
+	UpdateOrigin   string    `json:"updateOrigin,omitempty"`
+	UpdateType     string    `json:"updateType,omitempty"`
+	RollbackSource string    `json:"rollbackSource,omitempty"`
+	IsLegacy       bool      `json:"isLegacy,omitempty"`
+}
+
+// User represents the user who published a Remote Config template.
+type User struct {
+	Email    string `json:"email,omitempty"`
+	Name     string `json:"name,omitempty"`
+	ImageURL string `json:"imageUrl,omitempty"`
+}
+
+// Client is the interface for the Firebase Remote Config service.
+type Client struct {
+	// To enable testing against arbitrary endpoints.
+	endpoint string
+	client   *internal.HTTPClient
+	project  string
+}
+
+// NewClient creates a new instance of the Firebase Remote Config Client.
+//
+// This function can only be invoked from within the SDK. Client applications should access the
+// Remote Config service through firebase.App.
+func NewClient(ctx context.Context, c *internal.RemoteConfigConfig) (*Client, error) {
+	if c.ProjectID == "" {
+		return nil, errors.New("project id is required to access remote config client")
+	}
+
+	hc, _, err := internal.NewHTTPClient(ctx, c.Opts...)
+	if err != nil {
+		return nil, err
+	}
+	hc.Client = c.WrapTransport.Wrap(hc.Client)
+
+	baseURL := defaultRemoteConfigURL
+	if c.Endpoint != "" {
+		baseURL = strings.TrimSuffix(c.Endpoint, "/")
+	}
+
+	hc.CreateErrFn = createError
+	hc.Opts = []internal.HTTPOption{
+		internal.WithHeader(firebaseClientHeader, fmt.Sprintf("fire-admin-go/%s", c.Version)),
+	}
+	return &Client{
+		endpoint: baseURL + "/v1",
+		client:   hc,
+		project:  c.ProjectID,
+	}, nil
+}
+
+// GetTemplate retrieves the currently active Remote Config template of the project.
+func (c *Client) GetTemplate(ctx context.Context) (*Template, error) {
+	var t Template
+	resp, err := c.client.DoAndUnmarshal(ctx, &internal.Request{
+		Method: http.MethodGet,
+		URL:    c.templateURL(),
+	}, &t)
+	if err != nil {
+		return nil, err
+	}
+
+	t.ETag = resp.Header.Get(etagHeader)
+	return &t, nil
+}
+
+// PublishTemplate publishes the given template, and makes it the active Remote Config template
+// of the project.
+//
+// The publish request is conditional on the ETag of the template. It fails with a
+// FailedPrecondition error if the template on the server has changed since it was retrieved.
+// Set the ETag to ForceETag to publish the template unconditionally.
+//
+// PublishTemplate returns the published template, which contains the new version metadata and
+// ETag.
+func (c *Client) PublishTemplate(ctx context.Context, t *Template) (*Template, error) {
+	return c.putTemplate(ctx, t, false)
+}
+
+// ValidateTemplate validates the given template on the server without publishing it.
+//
+// ValidateTemplate returns the validated template with its ETag unchanged, so that it can be
+// passed to PublishTemplate afterwards.
+func (c *Client) ValidateTemplate(ctx context.Context, t *Template) (*Template, error) {
+	return c.putTemplate(ctx, t, true)
+}
+
+func (c *Client) putTemplate(ctx context.Context, t *Template, validateOnly bool) (*Template, error) {
+	if t == nil {
+		return nil, errors.New("template must not be nil")
+	}
+	if t.ETag == "" {
+		return nil, errors.New("template etag must not be empty; retrieve the template with " +
+			"GetTemplate or set the etag to ForceETag")
+	}
+
+	req := &internal.Request{
+		Method: http.MethodPut,
+		URL:    c.templateURL(),
+		Body:   internal.NewJSONEntity(newTemplateRequest(t)),
+		Opts: []internal.HTTPOption{
+			internal.WithHeader(ifMatchHeader, t.ETag),
+		},
+	}
+	if validateOnly {
+		req.Opts = append(req.Opts, internal.WithQueryParam("validateOnly", "true"))
+	}
+
+	var result Template
+	resp, err := c.client.DoAndUnmarshal(ctx, req, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if validateOnly {
+		result.ETag = t.ETag
+	} else {
+		result.ETag = resp.Header.Get(etagHeader)
+	}
+	return &result, nil
+}
+
+func createError(resp *internal.Response) error {
+	return internal.NewFirebaseErrorOnePlatform(resp)
+}
+
+func (c *Client) templateURL() string {
+	return fmt.Sprintf("%s/projects/%s/remoteConfig", c.endpoint, c.project)
+}
+
+// templateRequest is the payload of a publish request. The version metadata set by the server
+// is excluded from it.
+type templateRequest struct {
+	Conditions      []*Condition               `json:"conditions,omitempty"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty"`
+	ParameterGroups map[string]*ParameterGroup `json:"parameterGroups,omitempty"`
+	Version         *versionRequest            `json:"version,omitempty"`
+}
+
+type versionRequest struct {
+	Description string `json:"description"`
+}
+
+func newTemplateRequest(t *Template) *templateRequest {
+	req := &templateRequest{
+		Conditions:      t.Conditions,
+		Parameters:      t.Parameters,
+		ParameterGroups: t.ParameterGroups,
+	}
+	if t.Version != nil && t.Version.Description != "" {
+		req.Version = &versionRequest{Description: t.Version.Description}
+	}
+	return req
+}
@@ -0,0 +1,422 @@
+// Copyright 2026 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remoteconfig
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/errorutils"
+	"firebase.google.com/go/v4/internal"
+	"google.golang.org/api/option"
+)
+
+const (
+	testTemplatePath = "/projects/test-project/remoteConfig"
+	testETag         = "etag-123456789012-1"
+)
+
+var testRemoteConfigConfig = &internal.RemoteConfigConfig{
+	ProjectID: "test-project",
+	Opts: []option.ClientOption{
+		option.WithTokenSource(&internal.MockTokenSource{AccessToken: "test-token"}),
+	},
+	Version: "test-version",
+}
+
+const testTemplateResponse = `{
+  "conditions": [
+    {"name": "ios", "expression": "device.os == 'ios'", "tagColor": "GREEN"}
+  ],
+  "parameters": {
+    "welcome_message": {
+      "defaultValue": {"value": "Welcome"},
+      "conditionalValues": {"ios": {"useInAppDefault": true}},
+      "description": "Welcome message",
+      "valueType": "STRING"
+    }
+  },
+  "parameterGroups": {
+    "experiments": {
+      "description": "Experiment flags",
+      "parameters": {
+        "new_checkout": {"defaultValue": {"value": "false"}, "valueType": "BOOLEAN"}
+      }
+    }
+  },
+  "version": {
+    "versionNumber": "17",
+    "updateTime": "2026-03-01T10:00:00.000Z",
+    "updateUser": {"email": "ci@example.com"},
+    "description": "Nightly experiment rollout",
+    "updateOrigin": "ADMIN_SDK_NODE",
+    "updateType": "INCREMENTAL_UPDATE"
+  }
+}`
+
+var testTemplate = &Template{
+	Conditions: []*Condition{
+		{Name: "ios", Expression: "device.os == 'ios'", TagColor: "GREEN"},
+	},
+	Parameters: map[string]*Parameter{
+		"welcome_message": {
+			DefaultValue: &ParameterValue{Value: "Welcome"},
+			ConditionalValues: map[string]*ParameterValue{
+				"ios": {UseInAppDefault: true},
+			},
+			Description: "Welcome message",
+			ValueType:   String,
+		},
+	},
+	ParameterGroups: map[string]*ParameterGroup{
+		"experiments": {
+			Description: "Experiment flags",
+			Parameters: map[string]*Parameter{
+				"new_checkout": {
+					DefaultValue: &ParameterValue{Value: "false"},
+					ValueType:    Boolean,
+				},
+			},
+		},
+	},
+	Version: &Version{
+		VersionNumber: "17",
+		UpdateTime:    time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC),
+		UpdateUser:    &User{Email: "ci@example.com"},
+		Description:   "Nightly experiment rollout",
+		UpdateOrigin:  "ADMIN_SDK_NODE",
+		UpdateType:    "INCREMENTAL_UPDATE",
+	},
+	ETag: testETag,
+}
+
+// wantTemplateRequest is the payload expected when testTemplate is published.
+const wantTemplateRequest = `{
+  "conditions": [
+    {"name": "ios", "expression": "device.os == 'ios'", "tagColor": "GREEN"}
+  ],
+  "parameters": {
+    "welcome_message": {
+      "defaultValue": {"value": "Welcome"},
+      "conditionalValues": {"ios": {"useInAppDefault": true}},
+      "description": "Welcome message",
+      "valueType": "STRING"
+    }
+  },
+  "parameterGroups": {
+    "experiments": {
+      "description": "Experiment flags",
+      "parameters": {
+        "new_checkout": {"defaultValue": {"value": "false"}, "valueType": "BOOLEAN"}
+      }
+    }
+  },
+  "version": {"description": "Nightly experiment rollout"}
+}`
+
+type mockServer struct {
+	Resp   string
+	Status int
+	Header map[string]string
+
+	Req  *http.Request
+	Body []byte
+	srv  *httptest.Server
+}
+
+func (s *mockServer) Start(c *Client) *httptest.Server {
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Req = r
+		s.Body, _ = ioutil.ReadAll(r.Body)
+		for k, v := range s.Header {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if s.Status != 0 {
+			w.WriteHeader(s.Status)
+		}
+		w.Write([]byte(s.Resp))
+	}))
+	c.endpoint = s.srv.URL
+	return s.srv
+}
+
+func newTestClient(t *testing.T) *Client {
+	client, err := NewClient(context.Background(), testRemoteConfigConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func checkRequest(t *testing.T, r *http.Request, method, query, ifMatch string) {
+	if r == nil {
+		t.Fatalf("Request = nil; want non-nil")
+	}
+	if r.Method != method {
+		t.Errorf("Method = %q; want = %q", r.Method, method)
+	}
+	if r.URL.Path != testTemplatePath {
+		t.Errorf("Path = %q; want = %q", r.URL.Path, testTemplatePath)
+	}
+	if r.URL.RawQuery != query {
+		t.Errorf("Query = %q; want = %q", r.URL.RawQuery, query)
+	}
+	if h := r.Header.Get("If-Match"); h != ifMatch {
+		t.Errorf("If-Match = %q; want = %q", h, ifMatch)
+	}
+	if h := r.Header.Get("Authorization"); h != "Bearer test-token" {
+		t.Errorf("Authorization = %q; want = %q", h, "Bearer test-token")
+	}
+	if h := r.Header.Get("X-Firebase-Client"); h != "fire-admin-go/test-version" {
+		t.Errorf("X-Firebase-Client = %q; want = %q", h, "fire-admin-go/test-version")
+	}
+}
+
+func checkRequestBody(t *testing.T, b []byte, want string) {
+	var got, wantBody interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantBody); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, wantBody) {
+		t.Errorf("Body = %s; want = %s", string(b), want)
+	}
+}
+
+func TestNoProjectID(t *testing.T) {
+	client, err := NewClient(context.Background(), &internal.RemoteConfigConfig{})
+	if client != nil || err == nil {
+		t.Errorf("NewClient() = (%v, %v); want = (nil, error)", client, err)
+	}
+}
+
+func TestNewClientWithEndpoint(t *testing.T) {
+	conf := &internal.RemoteConfigConfig{
+		ProjectID: "test-project",
+		Opts:      testRemoteConfigConfig.Opts,
+		Endpoint:  "https://rc.example.com",
+	}
+	client, err := NewClient(context.Background(), conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.endpoint != "https://rc.example.com/v1" {
+		t.Errorf("endpoint = %q; want = %q", client.endpoint, "https://rc.example.com/v1")
+	}
+}
+
+func TestGetTemplate(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resp:   testTemplateResponse,
+		Header: map[string]string{"ETag": testETag},
+	}
+	defer s.Start(client).Close()
+
+	template, err := client.GetTemplate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(template, testTemplate) {
+		t.Errorf("GetTemplate() = %#v; want = %#v", template, testTemplate)
+	}
+	checkRequest(t, s.Req, http.MethodGet, "", "")
+}
+
+func TestGetTemplateError(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resp:   `{"error": {"status": "PERMISSION_DENIED", "message": "test error"}}`,
+		Status: http.StatusForbidden,
+	}
+	defer s.Start(client).Close()
+
+	template, err := client.GetTemplate(context.Background())
+	if template != nil || err == nil || err.Error() != "test error" {
+		t.Errorf("GetTemplate() = (%v, %v); want = (nil, %q)", template, err, "test error")
+	}
+	if !errorutils.IsPermissionDenied(err) {
+		t.Errorf("GetTemplate() = %v; want = PermissionDenied", err)
+	}
+}
+
+func TestPublishTemplate(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resp:   testTemplateResponse,
+		Header: map[string]string{"ETag": "etag-123456789012-2"},
+	}
+	defer s.Start(client).Close()
+
+	template, err := client.PublishTemplate(context.Background(), testTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if template.ETag != "etag-123456789012-2" {
+		t.Errorf("PublishTemplate().ETag = %q; want = %q", template.ETag, "etag-123456789012-2")
+	}
+	if !reflect.DeepEqual(template.Version, testTemplate.Version) {
+		t.Errorf("PublishTemplate().Version = %v; want = %v", template.Version, testTemplate.Version)
+	}
+	checkRequest(t, s.Req, http.MethodPut, "", testETag)
+	checkRequestBody(t, s.Body, wantTemplateRequest)
+}
+
+func TestPublishTemplateForce(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resp:   "{}",
+		Header: map[string]string{"ETag": "etag-123456789012-2"},
+	}
+	defer s.Start(client).Close()
+
+	template := &Template{
+		Parameters: map[string]*Parameter{
+			"empty": {DefaultValue: &ParameterValue{}},
+		},
+		ETag: ForceETag,
+	}
+	if _, err := client.PublishTemplate(context.Background(), template); err != nil {
+		t.Fatal(err)
+	}
+
+	checkRequest(t, s.Req, http.MethodPut, "", "*")
+	checkRequestBody(t, s.Body, `{"parameters": {"empty": {"defaultValue": {"value": ""}}}}`)
+}
+
+func TestPublishTemplateETagMismatch(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resp:   `{"error": {"status": "FAILED_PRECONDITION", "message": "etag mismatch"}}`,
+		Status: http.StatusPreconditionFailed,
+	}
+	defer s.Start(client).Close()
+
+	template, err := client.PublishTemplate(context.Background(), testTemplate)
+	if template != nil || err == nil || err.Error() != "etag mismatch" {
+		t.Errorf("PublishTemplate() = (%v, %v); want = (nil, %q)", template, err, "etag mismatch")
+	}
+	if !errorutils.IsFailedPrecondition(err) {
+		t.Errorf("PublishTemplate() = %v; want = FailedPrecondition", err)
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	client := newTestClient(t)
+	s := &mockServer{
+		Resp:   testTemplateResponse,
+		Header: map[string]string{"ETag": "etag-123456789012-0"},
+	}
+	defer s.Start(client).Close()
+
+	template, err := client.ValidateTemplate(context.Background(), testTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if template.ETag != testETag {
+		t.Errorf("ValidateTemplate().ETag = %q; want = %q", template.ETag, testETag)
+	}
+	checkRequest(t, s.Req, http.MethodPut, "validateOnly=true", testETag)
+	checkRequestBody(t, s.Body, wantTemplateRequest)
+}
+
+func TestTemplateRoundTrip(t *testing.T) {
+	const values = `{
+	  "parameters": {
+	    "price": {
+	      "defaultValue": {"personalizationValue": {"personalizationId": "p-1"}},
+	      "conditionalValues": {
+	        "beta": {"rolloutValue": {"rolloutId": "rollout_1", "value": "9.99", "percent": 25}},
+	        "exp": {"experimentValue": {"experimentId": "exp_1", "variantValue": []}}
+	      }
+	    }
+	  }
+	}`
+	client := newTestClient(t)
+	s := &mockServer{
+		Resp:   values,
+		Header: map[string]string{"ETag": testETag},
+	}
+	defer s.Start(client).Close()
+
+	template, err := client.GetTemplate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	price := template.Parameters["price"]
+	wantPersonalization := &PersonalizationValue{PersonalizationID: "p-1"}
+	if !reflect.DeepEqual(price.DefaultValue.PersonalizationValue, wantPersonalization) {
+		t.Errorf("PersonalizationValue = %v; want = %v", price.DefaultValue.PersonalizationValue, wantPersonalization)
+	}
+	wantRollout := &RolloutValue{RolloutID: "rollout_1", Value: "9.99", Percent: 25}
+	if rollout := price.ConditionalValues["beta"].RolloutValue; !reflect.DeepEqual(rollout, wantRollout) {
+		t.Errorf("RolloutValue = %v; want = %v", rollout, wantRollout)
+	}
+
+	if _, err := client.PublishTemplate(context.Background(), template); err != nil {
+		t.Fatal(err)
+	}
+	checkRequest(t, s.Req, http.MethodPut, "", testETag)
+	checkRequestBody(t, s.Body, values)
+}
+
+func TestParameterValueOverridesUnknownKind(t *testing.T) {
+	var v ParameterValue
+	if err := json.Unmarshal([]byte(`{"experimentValue": {"experimentId": "exp_1"}}`), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	v.Value = "new value"
+	b, err := json.Marshal(&v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"value":"new value"}` {
+		t.Errorf("Marshal() = %s; want = %s", string(b), `{"value":"new value"}`)
+	}
+}
+
+func TestPutTemplateInvalidArgs(t *testing.T) {
+	client := newTestClient(t)
+	cases := []struct {
+		name     string
+		template *Template
+	}{
+		{"nil", nil},
+		{"no etag", &Template{}},
+	}
+
+	for _, tc := range cases {
+		if template, err := client.PublishTemplate(context.Background(), tc.template); template != nil || err == nil {
+			t.Errorf("PublishTemplate(%s) = (%v, %v); want = (nil, error)", tc.name, template, err)
+		}
+		if template, err := client.ValidateTemplate(context.Background(), tc.template); template != nil || err == nil {
+			t.Errorf("ValidateTemplate(%s) = (%v, %v); want = (nil, error)", tc.name, template, err)
+		}
+	}
+}